@@ -1,11 +1,11 @@
 package bot
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
-	"net/http"
+	"sort"
 	"strings"
 	"time"
 )
@@ -27,23 +27,33 @@ import (
 
 type any = interface{}
 
-// Msg 飞书消息结构
+// Msg 飞书消息结构，支持自定义机器人的所有消息类型
+// 消息卡片（interactive）使用 Card 字段，其余类型使用 Content 字段
 type Msg struct {
-	MsgType string `json:"msg_type"`
-	Card    Card   `json:"card"`
+	MsgType   string   `json:"msg_type"`
+	Card      Card     `json:"card"`
+	Content   *Content `json:"content,omitempty"`   // 非卡片消息的内容
+	Timestamp string   `json:"timestamp,omitempty"` // 签名时间戳（开启签名校验时必填）
+	Sign      string   `json:"sign,omitempty"`      // 签名（开启签名校验时必填）
 }
 
 // Text 文本对象
 type Text struct {
 	Content string `json:"content,omitempty"`
 	Tag     string `json:"tag"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
 }
 
 // Header 卡片头部
 type Header struct {
 	Title    Text   `json:"title"`
+	Subtitle *Text  `json:"subtitle,omitempty"` // 副标题（卡片2.0新增）
 	Template string `json:"template,omitempty"`
 	UdIcon   *Icon  `json:"ud_icon,omitempty"` // 自定义图标（卡片2.0新增）
+	Padding  string `json:"padding,omitempty"` // 内边距，如 "12px 12px 12px 12px"（卡片2.0新增）
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
 }
 
 // Icon 图标对象
@@ -51,12 +61,20 @@ type Icon struct {
 	Tag   string `json:"tag"`
 	Token string `json:"token"`
 	Color string `json:"color,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
 }
 
 // Config 卡片全局配置（卡片2.0新增）
 type Config struct {
-	WideScreenMode bool `json:"wide_screen_mode,omitempty"` // 是否启用宽屏模式
-	EnableForward  bool `json:"enable_forward,omitempty"`   // 是否允许转发
+	WideScreenMode bool     `json:"wide_screen_mode,omitempty"` // 是否启用宽屏模式（卡片1.0）
+	EnableForward  bool     `json:"enable_forward,omitempty"`   // 是否允许转发
+	UpdateMulti    bool     `json:"update_multi,omitempty"`     // 是否为共享卡片（卡片2.0仅支持 true）
+	WidthMode      string   `json:"width_mode,omitempty"`       // 宽度模式：compact、fill（卡片2.0）
+	StreamingMode  bool     `json:"streaming_mode,omitempty"`   // 是否开启流式更新（卡片2.0）
+	Summary        *Summary `json:"summary,omitempty"`          // 会话列表中的卡片摘要（卡片2.0）
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
 }
 
 // CardLink 卡片链接
@@ -65,15 +83,22 @@ type CardLink struct {
 	AndroidUrl string `json:"android_url,omitempty"`
 	IosUrl     string `json:"ios_url,omitempty"`
 	PCUrl      string `json:"pc_url,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
 }
 
 // Card 卡片主体
+// Schema 为 "2.0" 时按卡片 JSON 2.0 结构输出（schema/body），否则按 1.0 结构输出（elements）
 type Card struct {
-	Config       *Config   `json:"config,omitempty"`         // 全局配置
-	Header       Header    `json:"header"`
-	Elements     []Element `json:"elements"`
-	CardLink     *CardLink `json:"card_link,omitempty"`      // 卡片链接
+	Schema       string        `json:"schema,omitempty"` // 卡片结构版本
+	Config       *Config       `json:"config,omitempty"` // 全局配置
+	Header       Header        `json:"header"`
+	Elements     []CardElement `json:"elements"`
+	Body         *CardBody     `json:"body,omitempty"`          // 卡片正文（卡片2.0）
+	CardLink     *CardLink     `json:"card_link,omitempty"`     // 卡片链接
 	I18nElements *I18nElements `json:"i18n_elements,omitempty"` // 国际化元素
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
 }
 
 // I18nElements 国际化元素配置
@@ -81,22 +106,30 @@ type I18nElements struct {
 	ZhCn *I18nElement `json:"zh_cn,omitempty"` // 中文
 	EnUs *I18nElement `json:"en_us,omitempty"` // 英文
 	JaJp *I18nElement `json:"ja_jp,omitempty"` // 日文
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
 }
 
 // I18nElement 单个语言的元素配置
 type I18nElement struct {
-	Elements []Element `json:"elements"`
-	Header   *Header   `json:"header,omitempty"`
+	Elements []CardElement `json:"elements"`
+	Header   *Header       `json:"header,omitempty"`
+
+	arrayForm bool // 解码自组件数组形式，序列化时同样输出数组
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
 }
 
 // Column 表示卡片中多列布局中的一列。可以包含多个元素，例如文本、图片等
 type Column struct {
-	Tag           string    `json:"tag"`
-	Width         string    `json:"width,omitempty"`
-	Weight        int       `json:"weight,omitempty"`
-	Elements      []Element `json:"elements"`
-	VerticalAlign string    `json:"vertical_align,omitempty"`
-	Padding       *Padding  `json:"padding,omitempty"` // 内边距
+	Tag           string        `json:"tag"`
+	Width         string        `json:"width,omitempty"`
+	Weight        int           `json:"weight,omitempty"`
+	Elements      []CardElement `json:"elements"`
+	VerticalAlign string        `json:"vertical_align,omitempty"`
+	Padding       *Padding      `json:"padding,omitempty"` // 内边距
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
 }
 
 // Padding 内边距配置
@@ -105,23 +138,32 @@ type Padding struct {
 	Right  string `json:"right,omitempty"`
 	Bottom string `json:"bottom,omitempty"`
 	Left   string `json:"left,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
 }
 
 // Action 表示卡片中的一个交互组件，例如按钮、选择器等
 type Action struct {
-	Tag     string      `json:"tag"`
-	Text    *Text       `json:"text,omitempty"`
-	Url     string      `json:"url,omitempty"`
-	Type    string      `json:"type,omitempty"`
-	Value   any         `json:"value,omitempty"`
-	Confirm *Confirm    `json:"confirm,omitempty"` // 二次确认弹窗
-	Options []*Option   `json:"options,omitempty"` // 下拉选项
+	Tag     string    `json:"tag"`
+	Text    *Text     `json:"text,omitempty"`
+	Url     string    `json:"url,omitempty"`
+	Type    string    `json:"type,omitempty"`
+	Value   any       `json:"value,omitempty"`
+	Confirm *Confirm  `json:"confirm,omitempty"` // 二次确认弹窗
+	Options []*Option `json:"options,omitempty"` // 下拉选项
+
+	Name       string `json:"name,omitempty"`        // 表单中的组件名称，在卡片内唯一
+	ActionType string `json:"action_type,omitempty"` // 按钮的交互类型：link、request、multi、form_submit、form_reset
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
 }
 
 // Confirm 二次确认弹窗配置
 type Confirm struct {
-	Title Text    `json:"title"`
-	Text  Text    `json:"text"`
+	Title Text `json:"title"`
+	Text  Text `json:"text"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
 }
 
 // Option 下拉选项
@@ -129,6 +171,8 @@ type Option struct {
 	Text  Text   `json:"text"`
 	Value string `json:"value"`
 	Url   string `json:"url,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
 }
 
 // Element 表示卡片中的一个元素，可以是多种类型，例如文本、图片、按钮等
@@ -142,26 +186,28 @@ type Element struct {
 	Columns           []Column  `json:"columns,omitempty"`
 	Actions           []Action  `json:"actions,omitempty"`
 	Elements          []Element `json:"elements,omitempty"`
-	
+
 	// 图片相关字段
-	ImgKey            string    `json:"img_key,omitempty"`
-	Alt               *Text     `json:"alt,omitempty"`
-	Title             *Text     `json:"title,omitempty"`
-	CustomWidth       string    `json:"custom_width,omitempty"`
-	CompactWidth      bool      `json:"compact_width,omitempty"`
-	Mode              string    `json:"mode,omitempty"`
-	
+	ImgKey       string `json:"img_key,omitempty"`
+	Alt          *Text  `json:"alt,omitempty"`
+	Title        *Text  `json:"title,omitempty"`
+	CustomWidth  string `json:"custom_width,omitempty"`
+	CompactWidth bool   `json:"compact_width,omitempty"`
+	Mode         string `json:"mode,omitempty"`
+
 	// 文本样式
-	Text              *Text     `json:"text,omitempty"`
-	Extra             *Element  `json:"extra,omitempty"`
-	Field             *Field    `json:"field,omitempty"`
-	IsShort           bool      `json:"is_short,omitempty"`
+	Text    *Text    `json:"text,omitempty"`
+	Extra   *Element `json:"extra,omitempty"`
+	Field   *Field   `json:"field,omitempty"`
+	IsShort bool     `json:"is_short,omitempty"`
 }
 
 // Field 字段对象（用于div模块）
 type Field struct {
 	IsShort bool  `json:"is_short"`
 	Text    *Text `json:"text"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
 }
 
 // CreateMarkdownElement 构建一个 Markdown 元素，用于显示富文本内容
@@ -207,7 +253,7 @@ func CreateColumn(align, content string) Column {
 		Width:         "weighted",
 		Weight:        1,
 		VerticalAlign: align,
-		Elements: []Element{
+		Elements: []CardElement{
 			CreateMarkdownElement(content),
 		},
 	}
@@ -220,7 +266,7 @@ func CreateCenterColumn(align, content string) Column {
 		Width:         "weighted",
 		Weight:        1,
 		VerticalAlign: align,
-		Elements: []Element{
+		Elements: []CardElement{
 			CreateMarkdownCenterElement(content),
 		},
 	}
@@ -298,60 +344,137 @@ const (
 )
 
 type FeishuMsg struct {
-	Title         string         `json:"title"`                    // 标题
-	Markdown      map[string]any `json:"markdown,omitempty"`       // 内容 (map形式，可能无序)
-	MarkdownItems []Text         `json:"markdown_items,omitempty"` // 内容 (切片形式，保持顺序)
-	MarkdownArray [][2]string    `json:"markdown_array,omitempty"` // 内容 (键值对数组形式，最简洁)
-	Note          string         `json:"note"`                     // 备注
-	NoteEmoji     bool           `json:"note_emoji"`               // 是否备注附带随机emoji表情
-	Link          string         `json:"link,omitempty"`           // 链接
-	HeaderColor   FeishuColor    `json:"-"`                        // 标题颜色
-	Response      any            `json:"response,omitempty"`       // 响应内容
-	
+	Title         string            `json:"title"`                    // 标题
+	Markdown      map[string]any    `json:"markdown,omitempty"`       // 内容 (map形式，默认按键排序)
+	MarkdownItems []Text            `json:"markdown_items,omitempty"` // 内容 (切片形式，保持顺序)
+	MarkdownArray [][2]string       `json:"markdown_array,omitempty"` // 内容 (键值对数组形式，最简洁)
+	Note          string            `json:"note"`                     // 备注
+	NoteEmoji     bool              `json:"note_emoji"`               // 是否备注附带随机emoji表情
+	Link          string            `json:"link,omitempty"`           // 链接
+	HeaderColor   FeishuColor       `json:"-"`                        // 标题颜色
+	Response      any               `json:"response,omitempty"`       // 响应内容
+	Labels        map[string]string `json:"labels,omitempty"`         // 标签（severity、service、env、team 等），用于路由
+
 	// 卡片2.0新增字段
-	WideScreen    bool           `json:"-"`                        // 是否启用宽屏模式
-	EnableForward bool           `json:"-"`                        // 是否允许转发
-	CustomIcon    *Icon          `json:"-"`                        // 自定义图标
-	Actions       []Action       `json:"-"`                        // 交互组件（按钮等）
-	Images        []string       `json:"-"`                        // 图片列表（img_key）
+	WideScreen    bool     `json:"-"` // 是否启用宽屏模式
+	EnableForward bool     `json:"-"` // 是否允许转发
+	CustomIcon    *Icon    `json:"-"` // 自定义图标
+	Actions       []Action `json:"-"` // 交互组件（按钮等）
+	Images        []string `json:"-"` // 图片列表（img_key）
+
+	Tables []TableElement `json:"-"` // 表格，显示在内容之后、图片之前
+	Charts []ChartElement `json:"-"` // 图表，显示在表格之后、图片之前
+
+	Details         string `json:"-"` // 详细信息（堆栈、日志等），放在默认收起的折叠面板中
+	DetailsTitle    string `json:"-"` // 折叠面板的标题，默认 DefaultDetailsTitle
+	DetailsMarkdown bool   `json:"-"` // 详细信息按 markdown 渲染，默认放在代码块中原样显示
+
+	Schema  CardSchema `json:"-"` // 卡片结构版本，默认 1.0
+	Summary string     `json:"-"` // 会话列表中的卡片摘要（卡片2.0）
+
+	MarkdownOrder []string               `json:"-"` // Markdown 的输出顺序，未列出的键按字典序排在后面
+	MarkdownLess  func(a, b string) bool `json:"-"` // 自定义 Markdown 键的排序规则，优先于 MarkdownOrder
+
+	MaxValueRunes int `json:"-"` // 每项内容的最大字符数，超出时截断，0 表示不限制
+	MaxBodyRunes  int `json:"-"` // 全部内容的最大字符数，超出时截断，0 表示不限制（仅 markdown 布局）
+
+	Layout          Layout `json:"-"` // 键值对的布局方式，默认 markdown
+	ShortValueRunes int    `json:"-"` // 并排展示的值的最大字符数，0 表示使用 DefaultShortValueRunes
 }
 
-// buildMarkdownContent 构建markdown内容字符串
+// markdownEntries 按顺序返回所有内容项，Tag 为键，Content 为值，Tag 为空表示纯内容
 // 支持同时使用多种格式，按顺序输出：Markdown -> MarkdownItems -> MarkdownArray
-func (f *FeishuMsg) buildMarkdownContent() string {
-	var md strings.Builder
+func (f *FeishuMsg) markdownEntries() []Text {
+	entries := make([]Text, 0, len(f.Markdown)+len(f.MarkdownItems)+len(f.MarkdownArray))
 
-	// 1. 处理 Markdown map（可能无序）
-	if len(f.Markdown) > 0 {
-		for k, v := range f.Markdown {
-			md.WriteString(fmt.Sprintf("**%s**：%s\n", k, v))
-		}
+	// 1. 处理 Markdown map（按 markdownKeys 的顺序）
+	for _, k := range f.markdownKeys() {
+		entries = append(entries, Text{Tag: k, Content: fmt.Sprint(f.Markdown[k])})
 	}
 
 	// 2. 处理 MarkdownItems（保持顺序，支持混合内容）
-	if len(f.MarkdownItems) > 0 {
-		for _, item := range f.MarkdownItems {
-			if item.Tag != "" {
-				// 如果有 Tag，则格式化为键值对形式
-				md.WriteString(fmt.Sprintf("**%s**：%s\n", item.Tag, item.Content))
-			} else {
-				// 如果没有 Tag，直接使用 Content
-				md.WriteString(item.Content)
-				md.WriteString("\n")
+	entries = append(entries, f.MarkdownItems...)
+
+	// 3. 处理 MarkdownArray（最简洁的键值对）
+	for _, arr := range f.MarkdownArray {
+		entries = append(entries, Text{Tag: arr[0], Content: arr[1]})
+	}
+	return entries
+}
+
+// markdownKeys 返回 Markdown 的键，保证每次输出的顺序一致
+// 优先使用 MarkdownLess，其次按 MarkdownOrder 排列（未列出的键排在后面），默认按字典序
+func (f *FeishuMsg) markdownKeys() []string {
+	keys := make([]string, 0, len(f.Markdown))
+	for k := range f.Markdown {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	switch {
+	case f.MarkdownLess != nil:
+		sort.SliceStable(keys, func(i, j int) bool {
+			return f.MarkdownLess(keys[i], keys[j])
+		})
+	case len(f.MarkdownOrder) > 0:
+		rank := make(map[string]int, len(f.MarkdownOrder))
+		for i, k := range f.MarkdownOrder {
+			if _, ok := rank[k]; !ok {
+				rank[k] = i
 			}
 		}
+		position := func(k string) int {
+			if i, ok := rank[k]; ok {
+				return i
+			}
+			return len(f.MarkdownOrder)
+		}
+		sort.SliceStable(keys, func(i, j int) bool {
+			return position(keys[i]) < position(keys[j])
+		})
 	}
+	return keys
+}
 
-	// 3. 处理 MarkdownArray（最简洁的键值对）
-	if len(f.MarkdownArray) > 0 {
-		for _, arr := range f.MarkdownArray {
-			md.WriteString(fmt.Sprintf("**%s**：%s\n", arr[0], arr[1]))
+// formatMarkdownEntry 格式化单个内容项
+func formatMarkdownEntry(item Text) string {
+	if item.Tag != "" {
+		// 如果有 Tag，则格式化为键值对形式
+		return fmt.Sprintf("**%s**：%s\n", item.Tag, item.Content)
+	}
+	// 如果没有 Tag，直接使用 Content
+	return item.Content + "\n"
+}
+
+// contentEntries 返回按 MaxValueRunes 截断后的内容项
+func (f *FeishuMsg) contentEntries() []Text {
+	entries := f.markdownEntries()
+	if f.MaxValueRunes > 0 {
+		for i := range entries {
+			entries[i].Content = truncateMarkdown(entries[i].Content, f.MaxValueRunes, f.Link)
 		}
 	}
+	return entries
+}
 
+// formatMarkdownEntries 将多个内容项格式化为一段 markdown
+func formatMarkdownEntries(entries []Text) string {
+	var md strings.Builder
+	for _, item := range entries {
+		md.WriteString(formatMarkdownEntry(item))
+	}
 	return md.String()
 }
 
+// buildMarkdownContent 构建markdown内容字符串，按 MaxValueRunes、MaxBodyRunes 截断
+func (f *FeishuMsg) buildMarkdownContent() string {
+	content := formatMarkdownEntries(f.contentEntries())
+	if f.MaxBodyRunes > 0 {
+		return truncateMarkdown(content, f.MaxBodyRunes, f.Link)
+	}
+	return content
+}
+
 // buildNoteContent 构建备注内容
 func (f *FeishuMsg) buildNoteContent() string {
 	note := f.Note
@@ -368,14 +491,25 @@ func (f *FeishuMsg) buildNoteContent() string {
 	return note
 }
 
-// FormatMsg 构造一个统计消息卡片（飞书卡片2.0）
+// FormatMsg 构造一个统计消息卡片，f.Schema 为 CardSchemaV2 时输出卡片 JSON 2.0 结构
 func FormatMsg(f *FeishuMsg) *Msg {
-	elements := make([]Element, 0)
+	v2 := f.Schema == CardSchemaV2
+	elements := make([]CardElement, 0)
 
 	// 添加markdown内容
-	mdContent := f.buildMarkdownContent()
-	if mdContent != "" {
-		elements = append(elements, CreateMarkdownElement(mdContent))
+	elements = append(elements, f.buildContentElements()...)
+
+	// 添加表格（如果有）
+	elements = append(elements, createTableElements(f.Tables)...)
+
+	// 添加图表（如果有）
+	for _, chart := range f.Charts {
+		elements = append(elements, chart)
+	}
+
+	// 添加详细信息（如果有）
+	if f.Details != "" {
+		elements = append(elements, f.buildDetailsElement())
 	}
 
 	// 添加图片（如果有）
@@ -387,15 +521,23 @@ func FormatMsg(f *FeishuMsg) *Msg {
 
 	// 添加交互组件（如果有）
 	if len(f.Actions) > 0 {
-		elements = append(elements, Element{
-			Tag:     "action",
-			Actions: f.Actions,
-		})
+		if v2 {
+			elements = append(elements, createButtonsElementV2(f.Actions))
+		} else {
+			elements = append(elements, Element{
+				Tag:     "action",
+				Actions: f.Actions,
+			})
+		}
 	}
 
 	// 添加备注
 	noteContent := f.buildNoteContent()
-	elements = append(elements, CreateNoteElement(noteContent))
+	if v2 {
+		elements = append(elements, createNoteElementV2(noteContent))
+	} else {
+		elements = append(elements, CreateNoteElement(noteContent))
+	}
 
 	// 构建卡片链接
 	var cardLink *CardLink
@@ -422,14 +564,27 @@ func FormatMsg(f *FeishuMsg) *Msg {
 		},
 		Template: string(f.HeaderColor),
 	}
-	
+
 	// 添加自定义图标（如果有）
 	if f.CustomIcon != nil {
 		header.UdIcon = f.CustomIcon
 	}
 
+	if v2 {
+		return &Msg{
+			MsgType: MsgTypeInteractive,
+			Card: Card{
+				Schema:   string(CardSchemaV2),
+				Config:   createConfigV2(f),
+				Header:   header,
+				Body:     &CardBody{Elements: elements},
+				CardLink: cardLink,
+			},
+		}
+	}
+
 	return &Msg{
-		MsgType: "interactive",
+		MsgType: MsgTypeInteractive,
 		Card: Card{
 			Config:   config,
 			Header:   header,
@@ -439,45 +594,14 @@ func FormatMsg(f *FeishuMsg) *Msg {
 	}
 }
 
-// SendFeishuMsg 发送消息到飞书
-func SendFeishuMsg(hook string, f *FeishuMsg) error {
-	if hook == "" {
-		return fmt.Errorf("hook url is empty")
-	}
-
-	// 将消息内容转换为JSON格式
-	msg := FormatMsg(f)
-	data, err := json.Marshal(msg)
-	if err != nil {
-		return fmt.Errorf("failed to marshal message: %w", err)
-	}
-
-	// 创建HTTP POST请求
-	req, err := http.NewRequest("POST", hook, bytes.NewReader(data))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	// 发送请求
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("request failed with status code %d", resp.StatusCode)
-	}
-
-	buf := new(bytes.Buffer)
-	_, err = buf.ReadFrom(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
+// SendFeishuMsg 发送消息到飞书，默认按 hook 限流，可通过 opts 追加客户端配置，如 WithValidation() 开启发送前校验
+func SendFeishuMsg(hook string, f *FeishuMsg, opts ...ClientOption) error {
+	opts = append([]ClientOption{WithHook(hook), WithRateLimit()}, opts...)
+	_, err := NewClient(opts...).Send(context.Background(), f)
+	return err
+}
 
-	f.Response = buf.String()
-	return nil
+// SendFeishuMsgWithSecret 发送消息到开启了签名校验的飞书机器人，secret 为空时不签名
+func SendFeishuMsgWithSecret(hook, secret string, f *FeishuMsg) error {
+	return SendFeishuMsg(hook, f, WithSecret(secret))
 }
@@ -118,20 +118,44 @@ func TestMarkdownPriority(t *testing.T) {
 	}
 
 	content := msg.buildMarkdownContent()
-	t.Logf("生成的内容:\n%s", content)
 
-	// 现在应该包含所有三种格式的内容
-	if !strings.Contains(content, "来自 Markdown map") {
-		t.Errorf("应该包含 Markdown 的内容")
+	// 应该包含所有三种格式的内容，并按 Markdown -> MarkdownItems -> MarkdownArray 的顺序输出
+	expected := "**Map格式**：来自 Markdown map\n**Items格式**：来自 MarkdownItems\n**Array格式**：来自 MarkdownArray\n"
+	if content != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, content)
+	}
+
+	t.Log("多格式同时使用测试通过")
+}
+
+func TestMarkdownMapOrder(t *testing.T) {
+	// 测试 Markdown map 默认按键排序，多次输出结果一致
+	expected := "**内容**：这是内容**粗体**, *斜体*, ~~删除线~~\n" +
+		"**列表**：\n- 列表1\n- 列表2\n- 列表3\n" +
+		"**时间**：2021-08-12 12:00:00快速\n" +
+		"**标题**：标题测试\n" +
+		"**状态**：<font color='green'>成功</font> <font color='red'>失败</font> <font color='grey'>灰色</font>\n" +
+		"**链接**：" + TmplTestFeishu.Markdown["链接"].(string) + "\n"
+	for i := 0; i < 10; i++ {
+		if content := TmplTestFeishu.buildMarkdownContent(); content != expected {
+			t.Fatalf("Expected:\n%s\nGot:\n%s", expected, content)
+		}
 	}
-	if !strings.Contains(content, "来自 MarkdownItems") {
-		t.Errorf("应该包含 MarkdownItems 的内容")
+
+	// 按 MarkdownOrder 指定的顺序输出，未列出的键按字典序排在后面
+	msg := &FeishuMsg{
+		Markdown:      map[string]any{"c": 3, "a": 1, "b": 2, "d": 4},
+		MarkdownOrder: []string{"d", "b", "x"},
 	}
-	if !strings.Contains(content, "来自 MarkdownArray") {
-		t.Errorf("应该包含 MarkdownArray 的内容")
+	if content := msg.buildMarkdownContent(); content != "**d**：4\n**b**：2\n**a**：1\n**c**：3\n" {
+		t.Errorf("MarkdownOrder 顺序不正确:\n%s", content)
 	}
 
-	t.Log("多格式同时使用测试通过")
+	// MarkdownLess 优先于 MarkdownOrder
+	msg.MarkdownLess = func(a, b string) bool { return a > b }
+	if content := msg.buildMarkdownContent(); content != "**d**：4\n**c**：3\n**b**：2\n**a**：1\n" {
+		t.Errorf("MarkdownLess 顺序不正确:\n%s", content)
+	}
 }
 
 // 测试卡片2.0 - 宽屏模式
@@ -177,8 +201,9 @@ func TestCardV2Actions(t *testing.T) {
 
 	// 查找 action 元素
 	hasAction := false
-	for _, elem := range card.Card.Elements {
-		if elem.Tag == "action" && len(elem.Actions) > 0 {
+	for _, e := range card.Card.Elements {
+		elem, ok := e.(Element)
+		if ok && elem.Tag == "action" && len(elem.Actions) > 0 {
 			hasAction = true
 			if len(elem.Actions) != 2 {
 				t.Errorf("应该有2个按钮，实际有 %d 个", len(elem.Actions))
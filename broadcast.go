@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Target 发送目标（机器人）
+type Target struct {
+	Name   string `json:"name,omitempty"`   // 目标名称，为空时使用 Hook 作为结果的键
+	Hook   string `json:"hook"`             // webhook 地址
+	Secret string `json:"secret,omitempty"` // 签名校验密钥
+}
+
+// key 返回目标在结果中的键
+func (t Target) key() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return t.Hook
+}
+
+// label 返回用于错误信息的目标名称，避免在日志中泄露完整的 webhook 地址
+func (t Target) label() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	if i := strings.LastIndex(t.Hook, "/"); i >= 0 && len(t.Hook)-i > 5 {
+		return t.Hook[:i+1] + "***" + t.Hook[len(t.Hook)-4:]
+	}
+	return t.Hook
+}
+
+// TargetResult 单个目标的发送结果
+type TargetResult struct {
+	Result *SendResult
+	Err    error
+}
+
+// BroadcastError 多目标发送时的聚合错误，键与结果的键一致
+type BroadcastError struct {
+	Total  int              // 目标总数
+	Errors map[string]error // 发送失败的目标
+	labels map[string]string
+}
+
+func (e *BroadcastError) Error() string {
+	keys := make([]string, 0, len(e.Errors))
+	for k := range e.Errors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("broadcast failed for %d of %d targets", len(e.Errors), e.Total))
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf("; %s: %v", e.labels[k], e.Errors[k]))
+	}
+	return sb.String()
+}
+
+// Unwrap 支持通过 errors.Is / errors.As 检查其中的错误
+func (e *BroadcastError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// withTarget 复制一个发往指定目标的客户端，共享其余配置
+func (c *Client) withTarget(t Target) *Client {
+	cc := *c
+	cc.hook = t.Hook
+	cc.secret = t.Secret
+	if c.rateLimits != nil {
+		cc.limiter = sharedRateLimiter(t.Hook, c.rateLimits)
+	}
+	return &cc
+}
+
+// Broadcast 将同一条 FeishuMsg 并发发送到多个目标，只格式化一次且不会修改 f
+func (c *Client) Broadcast(ctx context.Context, targets []Target, f *FeishuMsg) (map[string]*TargetResult, error) {
+	return c.BroadcastMsg(ctx, targets, c.format(f))
+}
+
+// BroadcastMsg 将同一条消息并发发送到多个目标
+// 返回每个目标的发送结果，任一目标失败时返回 *BroadcastError；目标的键重复时不发送并返回错误
+func (c *Client) BroadcastMsg(ctx context.Context, targets []Target, msg *Msg) (map[string]*TargetResult, error) {
+	labels := make(map[string]string, len(targets))
+	for _, t := range targets {
+		key := t.key()
+		if _, ok := labels[key]; ok {
+			return nil, fmt.Errorf("duplicate broadcast target %q", t.label())
+		}
+		labels[key] = t.label()
+	}
+
+	results := make(map[string]*TargetResult, len(targets))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, t := range targets {
+		key := t.key()
+		wg.Add(1)
+		go func(t Target, key string) {
+			defer wg.Done()
+			result, err := c.withTarget(t).SendMsg(ctx, msg)
+
+			mu.Lock()
+			results[key] = &TargetResult{Result: result, Err: err}
+			mu.Unlock()
+		}(t, key)
+	}
+	wg.Wait()
+
+	errs := make(map[string]error)
+	for key, r := range results {
+		if r.Err != nil {
+			errs[key] = r.Err
+		}
+	}
+	if len(errs) > 0 {
+		return results, &BroadcastError{Total: len(targets), Errors: errs, labels: labels}
+	}
+	return results, nil
+}
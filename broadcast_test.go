@@ -0,0 +1,94 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// 测试多目标发送：聚合错误且不修改原消息
+func TestBroadcast(t *testing.T) {
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer ok.Close()
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":19021,"msg":"sign match fail"}`))
+	}))
+	defer bad.Close()
+
+	targets := []Target{
+		{Name: "ops", Hook: ok.URL},
+		{Name: "dev", Hook: ok.URL, Secret: "dev_secret"},
+		{Name: "management", Hook: bad.URL, Secret: "wrong_secret"},
+	}
+
+	f := &FeishuMsg{Title: "测试多目标发送", Note: "广播"}
+	results, err := NewClient().Broadcast(context.Background(), targets, f)
+
+	var bErr *BroadcastError
+	if !errors.As(err, &bErr) {
+		t.Fatalf("应该返回 *BroadcastError，实际是 %v", err)
+	}
+	if bErr.Total != 3 || len(bErr.Errors) != 1 || bErr.Errors["management"] == nil {
+		t.Errorf("聚合错误不正确: %v", bErr)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Kind != ErrorKindSignFailure {
+		t.Errorf("应该能通过 errors.As 取出 *APIError，实际是 %v", err)
+	}
+	if !strings.Contains(err.Error(), "management") {
+		t.Errorf("错误信息应该包含目标名称: %v", err)
+	}
+
+	if len(results) != 3 || results["ops"].Err != nil || results["dev"].Err != nil {
+		t.Errorf("发送结果不正确: %+v", results)
+	}
+	if f.Response != nil {
+		t.Error("Broadcast 不应该修改传入的消息")
+	}
+}
+
+// 测试全部成功时不返回错误
+func TestBroadcastAllSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	targets := []Target{{Hook: server.URL + "/a"}, {Hook: server.URL + "/b"}}
+	results, err := NewClient().Broadcast(context.Background(), targets, &FeishuMsg{Title: "全部成功"})
+	if err != nil {
+		t.Fatalf("不应该返回错误: %v", err)
+	}
+	if results[server.URL+"/a"] == nil || results[server.URL+"/b"] == nil {
+		t.Errorf("未设置名称时应该以 hook 作为键: %+v", results)
+	}
+}
+
+// 测试目标的键重复时不发送并返回错误
+func TestBroadcastDuplicateTarget(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	targets := []Target{
+		{Name: "ops", Hook: server.URL + "/a"},
+		{Name: "ops", Hook: server.URL + "/b"},
+	}
+	results, err := NewClient().Broadcast(context.Background(), targets, &FeishuMsg{Title: "告警"})
+	if err == nil || !strings.Contains(err.Error(), "duplicate") || results != nil {
+		t.Errorf("重复的目标应该返回错误: %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("目标重复时不应该发送，实际请求 %d 次", calls)
+	}
+}
@@ -0,0 +1,226 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+/**
+ * @Description: 消息卡片构建器
+ * 链式构建任意组合的消息卡片，每一步都会校验参数，错误在 Build 时统一返回：
+ * msg, err := NewCard().Title("告警").Color(ColorRed).Markdown("内容").Hr().Note("备注").Build()
+ * 组件按调用顺序排列，按钮和备注在 Build 时按卡片结构版本（Schema）生成
+ */
+
+// feishuColors 卡片标题支持的颜色
+var feishuColors = map[FeishuColor]bool{
+	ColorBlue: true, ColorWathet: true, ColorTurquoise: true, ColorGreen: true, ColorYellow: true, ColorOrange: true,
+	ColorRed: true, ColorCarmine: true, ColorViolet: true, ColorGrey: true, ColorDefault: true,
+}
+
+// isValidColor 是否为卡片标题支持的颜色
+func isValidColor(c FeishuColor) bool {
+	return feishuColors[c]
+}
+
+// isValidURL 是否为 http(s) 链接
+func isValidURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// CardBuilder 消息卡片构建器
+type CardBuilder struct {
+	header     Header
+	link       *CardLink
+	schema     CardSchema
+	wideScreen bool
+	summary    string
+	elements   []func(v2 bool) CardElement
+	errs       []error
+}
+
+// NewCard 创建消息卡片构建器
+func NewCard() *CardBuilder {
+	return &CardBuilder{}
+}
+
+// errorf 记录构建错误
+func (b *CardBuilder) errorf(format string, args ...any) *CardBuilder {
+	b.errs = append(b.errs, fmt.Errorf(format, args...))
+	return b
+}
+
+// add 追加组件
+func (b *CardBuilder) add(elem func(v2 bool) CardElement) *CardBuilder {
+	b.elements = append(b.elements, elem)
+	return b
+}
+
+// Schema 设置卡片结构版本，默认 1.0
+func (b *CardBuilder) Schema(schema CardSchema) *CardBuilder {
+	if schema != CardSchemaV1 && schema != CardSchemaV2 {
+		return b.errorf("unsupported card schema %q", schema)
+	}
+	b.schema = schema
+	return b
+}
+
+// Title 设置标题
+func (b *CardBuilder) Title(title string) *CardBuilder {
+	if title == "" {
+		return b.errorf("card title is empty")
+	}
+	b.header.Title = Text{Content: title, Tag: "plain_text"}
+	return b
+}
+
+// Subtitle 设置副标题
+func (b *CardBuilder) Subtitle(subtitle string) *CardBuilder {
+	b.header.Subtitle = &Text{Content: subtitle, Tag: "plain_text"}
+	return b
+}
+
+// Color 设置标题颜色
+func (b *CardBuilder) Color(color FeishuColor) *CardBuilder {
+	if !isValidColor(color) {
+		return b.errorf("invalid header color %q", color)
+	}
+	b.header.Template = string(color)
+	return b
+}
+
+// Icon 设置标题图标，token 为图标库中的图标，如 bell_outlined
+func (b *CardBuilder) Icon(token string) *CardBuilder {
+	if token == "" {
+		return b.errorf("icon token is empty")
+	}
+	b.header.UdIcon = &Icon{Tag: "standard_icon", Token: token}
+	return b
+}
+
+// WideScreen 启用宽屏模式
+func (b *CardBuilder) WideScreen() *CardBuilder {
+	b.wideScreen = true
+	return b
+}
+
+// Summary 设置会话列表中的卡片摘要（卡片2.0）
+func (b *CardBuilder) Summary(summary string) *CardBuilder {
+	b.summary = summary
+	return b
+}
+
+// Markdown 追加 Markdown 内容
+func (b *CardBuilder) Markdown(content string) *CardBuilder {
+	if content == "" {
+		return b.errorf("element %d: markdown content is empty", len(b.elements))
+	}
+	elem := CreateMarkdownElement(content)
+	return b.add(func(bool) CardElement { return elem })
+}
+
+// Columns 追加多列布局
+func (b *CardBuilder) Columns(columns ...Column) *CardBuilder {
+	if len(columns) == 0 {
+		return b.errorf("element %d: column set has no columns", len(b.elements))
+	}
+	elem := CreateColumnSetElement(columns, "none")
+	return b.add(func(bool) CardElement { return elem })
+}
+
+// Hr 追加分割线
+func (b *CardBuilder) Hr() *CardBuilder {
+	return b.add(func(bool) CardElement { return Hr() })
+}
+
+// Image 追加图片
+func (b *CardBuilder) Image(imgKey, alt string) *CardBuilder {
+	if imgKey == "" {
+		return b.errorf("element %d: image key is empty", len(b.elements))
+	}
+	elem := CreateImageElement(imgKey, alt)
+	return b.add(func(bool) CardElement { return elem })
+}
+
+// Buttons 追加一组按钮，卡片2.0中转换为带 behaviors 的按钮组件
+func (b *CardBuilder) Buttons(actions ...Action) *CardBuilder {
+	if len(actions) == 0 {
+		return b.errorf("element %d: no buttons", len(b.elements))
+	}
+	for i, a := range actions {
+		if a.Text == nil || a.Text.Content == "" {
+			return b.errorf("element %d: button %d has no text", len(b.elements), i)
+		}
+		if a.Url != "" && !isValidURL(a.Url) {
+			return b.errorf("element %d: button %d has invalid url %q", len(b.elements), i, a.Url)
+		}
+	}
+	return b.add(func(v2 bool) CardElement {
+		if v2 {
+			return createButtonsElementV2(actions)
+		}
+		return Element{Tag: "action", Actions: actions}
+	})
+}
+
+// Note 追加备注，卡片2.0中转换为小号字体的 Markdown
+func (b *CardBuilder) Note(content string) *CardBuilder {
+	if content == "" {
+		return b.errorf("element %d: note content is empty", len(b.elements))
+	}
+	return b.add(func(v2 bool) CardElement {
+		if v2 {
+			return createNoteElementV2(content)
+		}
+		return CreateNoteElement(content)
+	})
+}
+
+// Element 追加任意组件
+func (b *CardBuilder) Element(elem CardElement) *CardBuilder {
+	if elem == nil {
+		return b.errorf("element %d: element is nil", len(b.elements))
+	}
+	return b.add(func(bool) CardElement { return elem })
+}
+
+// Link 设置卡片链接，点击卡片时跳转
+func (b *CardBuilder) Link(link string) *CardBuilder {
+	if !isValidURL(link) {
+		return b.errorf("invalid card link %q", link)
+	}
+	b.link = &CardLink{Url: link}
+	return b
+}
+
+// Build 构建消息卡片，返回构建过程中的所有错误
+func (b *CardBuilder) Build() (*Msg, error) {
+	errs := b.errs
+	if len(b.elements) == 0 {
+		errs = append(errs, errors.New("card has no elements"))
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to build card: %w", errors.Join(errs...))
+	}
+
+	v2 := b.schema == CardSchemaV2
+	elements := make([]CardElement, 0, len(b.elements))
+	for _, elem := range b.elements {
+		elements = append(elements, elem(v2))
+	}
+
+	card := Card{Header: b.header, CardLink: b.link}
+	if v2 {
+		card.Schema = string(CardSchemaV2)
+		card.Config = createConfigV2(&FeishuMsg{WideScreen: b.wideScreen, Summary: b.summary})
+		card.Body = &CardBody{Elements: elements}
+	} else {
+		if b.wideScreen {
+			card.Config = &Config{WideScreenMode: true}
+		}
+		card.Elements = elements
+	}
+	return NewCardMsg(&card), nil
+}
@@ -0,0 +1,111 @@
+package bot
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// 测试链式构建消息卡片
+func TestCardBuilder(t *testing.T) {
+	msg, err := NewCard().
+		Title("告警通知").
+		Color(ColorRed).
+		Icon("bell_outlined").
+		Markdown("**服务**：order-api").
+		Columns(CreateColumn("top", "左侧"), CreateColumn("top", "右侧")).
+		Hr().
+		Buttons(CreatePrimaryButtonElement("查看", "https://example.com")).
+		Note("来自监控系统").
+		Link("https://example.com/alert").
+		Build()
+	if err != nil {
+		t.Fatalf("构建失败: %v", err)
+	}
+
+	card := msg.Card
+	if msg.MsgType != MsgTypeInteractive {
+		t.Errorf("消息类型不正确: %s", msg.MsgType)
+	}
+	if card.Header.Title.Content != "告警通知" || card.Header.Template != "red" || card.Header.UdIcon.Token != "bell_outlined" {
+		t.Errorf("头部不正确: %+v", card.Header)
+	}
+	if card.CardLink == nil || card.CardLink.Url != "https://example.com/alert" {
+		t.Errorf("卡片链接不正确: %+v", card.CardLink)
+	}
+
+	expected := []string{"markdown", "column_set", "hr", "action", "note"}
+	if len(card.Elements) != len(expected) {
+		t.Fatalf("组件数量不正确: %d", len(card.Elements))
+	}
+	for i, tag := range expected {
+		if card.Elements[i].ElementTag() != tag {
+			t.Errorf("第 %d 个组件不正确: 期望 %s, 实际 %s", i, tag, card.Elements[i].ElementTag())
+		}
+	}
+	if err := msg.ValidateContent(); err != nil {
+		t.Errorf("构建的卡片应该有效: %v", err)
+	}
+}
+
+// 测试构建卡片2.0结构
+func TestCardBuilderSchemaV2(t *testing.T) {
+	// Schema 在按钮之后设置同样生效
+	msg, err := NewCard().
+		Title("发布审批").
+		Buttons(CreateButtonElement("通过", "https://example.com")).
+		Note("备注").
+		Schema(CardSchemaV2).
+		Summary("发布审批").
+		Build()
+	if err != nil {
+		t.Fatalf("构建失败: %v", err)
+	}
+
+	card := decodeCard(t, msg)
+	if card["schema"] != "2.0" {
+		t.Errorf("schema 不正确: %v", card["schema"])
+	}
+	elements := card["body"].(map[string]any)["elements"].([]any)
+	if len(elements) != 2 || elements[0].(map[string]any)["tag"] != "column_set" || elements[1].(map[string]any)["text_size"] != "notation" {
+		t.Errorf("组件不正确: %v", elements)
+	}
+}
+
+// 测试构建时的参数校验
+func TestCardBuilderErrors(t *testing.T) {
+	_, err := NewCard().
+		Title("").
+		Color("pink").
+		Markdown("").
+		Buttons(Action{Tag: "button"}).
+		Link("javascript:alert(1)").
+		Build()
+	if err == nil {
+		t.Fatal("参数错误应该返回错误")
+	}
+	for _, want := range []string{"title is empty", `invalid header color "pink"`, "markdown content is empty", "has no text", "invalid card link", "card has no elements"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("错误信息应该包含 %q: %v", want, err)
+		}
+	}
+
+	if _, err := NewCard().Title("标题").Build(); err == nil {
+		t.Error("没有组件应该返回错误")
+	}
+}
+
+// 测试追加自定义组件
+func TestCardBuilderElement(t *testing.T) {
+	msg, err := NewCard().
+		Title("标题").
+		Element(DivElement{Text: &Text{Tag: "lark_md", Content: "**内容**"}}).
+		Build()
+	if err != nil {
+		t.Fatalf("构建失败: %v", err)
+	}
+	data, _ := json.Marshal(msg)
+	if !strings.Contains(string(data), `"tag":"div"`) {
+		t.Errorf("应该包含自定义组件: %s", data)
+	}
+}
@@ -0,0 +1,120 @@
+package bot
+
+import (
+	"sort"
+	"time"
+)
+
+/**
+ * @Description: 图表组件
+ * 文档 https://open.feishu.cn/document/feishu-cards/card-components/content-components/chart
+ * 图表定义（chart_spec）使用 VChart 规范 https://www.visactor.io/vchart
+ * 提供常用图表的构建函数：时间序列（折线图、柱状图、面积图）、分类统计和饼图占比，
+ * 构建后可以设置 AspectRatio、ColorTheme、Preview 等属性
+ */
+
+// ChartSpec VChart 图表定义
+type ChartSpec map[string]any
+
+// ChartType 图表类型
+type ChartType string
+
+const (
+	ChartLine ChartType = "line" // 折线图
+	ChartBar  ChartType = "bar"  // 柱状图
+	ChartArea ChartType = "area" // 面积图
+	ChartPie  ChartType = "pie"  // 饼图
+)
+
+// 图表宽高比
+const (
+	ChartAspect1x1  = "1:1"
+	ChartAspect2x1  = "2:1"
+	ChartAspect4x3  = "4:3"
+	ChartAspect16x9 = "16:9"
+)
+
+// 图表主题色
+const (
+	ChartThemeBrand         = "brand"
+	ChartThemeRainbow       = "rainbow"
+	ChartThemeComplementary = "complementary"
+	ChartThemeConverse      = "converse"
+	ChartThemePrimary       = "primary"
+)
+
+// DefaultChartTimeLayout 时间序列默认的时间格式
+const DefaultChartTimeLayout = "01-02 15:04"
+
+// TimePoint 时间序列中的一个点
+type TimePoint struct {
+	T time.Time
+	V float64
+}
+
+// CategoryValue 分类及其数值，用于分类统计和饼图占比
+type CategoryValue struct {
+	Category string
+	Value    float64
+}
+
+// NewTimeSeriesChart 构建时间序列图表，点按时间排序，layout 为空时使用 DefaultChartTimeLayout
+func NewTimeSeriesChart(chartType ChartType, title string, points []TimePoint, layout string) ChartElement {
+	if layout == "" {
+		layout = DefaultChartTimeLayout
+	}
+	sorted := append([]TimePoint(nil), points...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].T.Before(sorted[j].T) })
+
+	values := make([]CategoryValue, 0, len(sorted))
+	for _, p := range sorted {
+		values = append(values, CategoryValue{Category: p.T.Format(layout), Value: p.V})
+	}
+	return NewCategoryChart(chartType, title, values)
+}
+
+// NewCategoryChart 构建分类统计图表，按传入顺序展示，chartType 为 ChartPie 时等同于 NewPieChart
+func NewCategoryChart(chartType ChartType, title string, values []CategoryValue) ChartElement {
+	if chartType == ChartPie {
+		return NewPieChart(title, values)
+	}
+	spec := ChartSpec{
+		"type":   string(chartType),
+		"data":   chartData(values),
+		"xField": "category",
+		"yField": "value",
+	}
+	setChartTitle(spec, title)
+	return ChartElement{ChartSpec: spec}
+}
+
+// NewPieChart 构建饼图，展示各分类的占比
+func NewPieChart(title string, shares []CategoryValue) ChartElement {
+	spec := ChartSpec{
+		"type":          string(ChartPie),
+		"data":          chartData(shares),
+		"categoryField": "category",
+		"valueField":    "value",
+		"outerRadius":   0.8,
+		"label":         map[string]any{"visible": true},
+		"legends":       map[string]any{"visible": true, "orient": "bottom"},
+	}
+	setChartTitle(spec, title)
+	return ChartElement{ChartSpec: spec}
+}
+
+// chartData 转换为 VChart 的数据格式
+func chartData(values []CategoryValue) map[string]any {
+	rows := make([]map[string]any, 0, len(values))
+	for _, v := range values {
+		rows = append(rows, map[string]any{"category": v.Category, "value": v.Value})
+	}
+	return map[string]any{"values": rows}
+}
+
+// setChartTitle 设置图表标题，为空时不显示
+func setChartTitle(spec ChartSpec, title string) {
+	if title != "" {
+		spec["title"] = map[string]any{"text": title}
+	}
+}
@@ -0,0 +1,96 @@
+package bot
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// 测试时间序列图表
+func TestNewTimeSeriesChart(t *testing.T) {
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	chart := NewTimeSeriesChart(ChartLine, "请求量", []TimePoint{
+		{T: day.Add(time.Hour), V: 20},
+		{T: day, V: 10},
+	}, "")
+
+	expected := `{
+		"tag": "chart",
+		"chart_spec": {
+			"type": "line",
+			"title": {"text": "请求量"},
+			"data": {"values": [
+				{"category": "01-02 00:00", "value": 10},
+				{"category": "01-02 01:00", "value": 20}
+			]},
+			"xField": "category",
+			"yField": "value"
+		}
+	}`
+	data, _ := json.Marshal(chart)
+	assertJSONEqual(t, []byte(expected), data)
+}
+
+// 测试分类统计和饼图
+func TestCategoryAndPieChart(t *testing.T) {
+	values := []CategoryValue{{"成功", 90}, {"失败", 10}}
+
+	bar := NewCategoryChart(ChartBar, "", values)
+	if bar.ChartSpec["type"] != "bar" || bar.ChartSpec["title"] != nil {
+		t.Errorf("柱状图不正确: %+v", bar.ChartSpec)
+	}
+
+	pie := NewCategoryChart(ChartPie, "占比", values)
+	if pie.ChartSpec["type"] != "pie" || pie.ChartSpec["categoryField"] != "category" || pie.ChartSpec["valueField"] != "value" {
+		t.Errorf("饼图不正确: %+v", pie.ChartSpec)
+	}
+	if pie.ChartSpec["xField"] != nil {
+		t.Errorf("饼图不应该有坐标轴字段: %+v", pie.ChartSpec)
+	}
+}
+
+// 测试图表属性、渲染、解码和校验
+func TestFeishuMsgCharts(t *testing.T) {
+	preview := false
+	chart := NewPieChart("占比", []CategoryValue{{"a", 1}})
+	chart.AspectRatio = ChartAspect16x9
+	chart.ColorTheme = ChartThemeRainbow
+	chart.Preview = &preview
+
+	f := &FeishuMsg{
+		Title:  "日报",
+		Charts: []ChartElement{chart},
+		Images: []string{"img_v2_xxx"},
+		Schema: CardSchemaV2,
+	}
+	msg := FormatMsg(f)
+	elements := msg.Card.Body.Elements
+	if elements[0].ElementTag() != "chart" || elements[1].ElementTag() != "img" {
+		t.Fatalf("图表应该在图片之前: %+v", elements)
+	}
+	if err := Validate(msg); err != nil {
+		t.Errorf("校验失败: %v", err)
+	}
+
+	data, _ := json.Marshal(chart)
+	elem, err := UnmarshalCardElement(data)
+	if err != nil {
+		t.Fatalf("解码失败: %v", err)
+	}
+	decoded, ok := elem.(ChartElement)
+	if !ok || decoded.AspectRatio != "16:9" || decoded.ColorTheme != "rainbow" || decoded.Preview == nil || *decoded.Preview {
+		t.Errorf("解码结果不正确: %+v", elem)
+	}
+
+	f.Charts = []ChartElement{{AspectRatio: "3:2", ColorTheme: "dark"}}
+	paths := validationPaths(t, Validate(FormatMsg(f)))
+	for _, p := range []string{
+		"card.body.elements[0].chart_spec",
+		"card.body.elements[0].aspect_ratio",
+		"card.body.elements[0].color_theme",
+	} {
+		if _, ok := paths[p]; !ok {
+			t.Errorf("缺少校验错误 %s: %v", p, paths)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout 默认请求超时时间
+const DefaultTimeout = 30 * time.Second
+
+// Client 飞书机器人客户端，可复用连接并在多个 goroutine 中并发使用
+type Client struct {
+	hook       string
+	secret     string
+	httpClient *http.Client
+	timeout    time.Duration
+	userAgent  string
+	headers    http.Header
+	retry      RetryPolicy
+	schema     CardSchema
+	validate   bool // 发送前使用 Validate 校验
+
+	limiter       *RateLimiter
+	rateLimits    []RateLimit // 非空时使用按 hook 共享的限流器
+	rateLimitMode RateLimitMode
+}
+
+// ClientOption 客户端配置项
+type ClientOption func(*Client)
+
+// WithHook 设置机器人 webhook 地址
+func WithHook(hook string) ClientOption {
+	return func(c *Client) {
+		c.hook = hook
+	}
+}
+
+// WithSecret 设置机器人签名校验密钥
+func WithSecret(secret string) ClientOption {
+	return func(c *Client) {
+		c.secret = secret
+	}
+}
+
+// WithHTTPClient 使用自定义的 http.Client（代理、连接池、中间件等）
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithTimeout 设置请求超时时间
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithUserAgent 设置请求的 User-Agent
+func WithUserAgent(ua string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithHeader 为每个请求添加一个基础请求头
+func WithHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		c.headers.Add(key, value)
+	}
+}
+
+// WithHeaders 为每个请求添加一组基础请求头
+func WithHeaders(headers http.Header) ClientOption {
+	return func(c *Client) {
+		for k, vs := range headers {
+			for _, v := range vs {
+				c.headers.Add(k, v)
+			}
+		}
+	}
+}
+
+// NewClient 创建飞书机器人客户端
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{
+		headers: make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.httpClient == nil {
+		timeout := c.timeout
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+		c.httpClient = &http.Client{Timeout: timeout}
+	} else if c.timeout > 0 {
+		// 复制一份，避免修改调用方传入的 http.Client
+		hc := *c.httpClient
+		hc.Timeout = c.timeout
+		c.httpClient = &hc
+	}
+
+	if c.rateLimits != nil {
+		c.limiter = sharedRateLimiter(c.hook, c.rateLimits)
+	}
+	return c
+}
+
+// Send 格式化并发送 FeishuMsg，原始响应内容写入 f.Response
+func (c *Client) Send(ctx context.Context, f *FeishuMsg) (*SendResult, error) {
+	result, err := c.send(ctx, c.format(f))
+	if result != nil {
+		f.Response = result.Raw
+	}
+	return result, err
+}
+
+// SendMsg 发送已构建好的消息
+func (c *Client) SendMsg(ctx context.Context, msg *Msg) (*SendResult, error) {
+	return c.send(ctx, msg)
+}
+
+// send 发送消息，按重试策略处理失败。不会修改传入的 msg
+func (c *Client) send(ctx context.Context, msg *Msg) (*SendResult, error) {
+	if c.hook == "" {
+		return nil, fmt.Errorf("hook url is empty")
+	}
+	validate := msg.ValidateContent
+	if c.validate {
+		validate = func() error { return Validate(msg) }
+	}
+	if err := validate(); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		if err := c.acquire(ctx); err != nil {
+			return nil, err
+		}
+
+		result, err := c.sendOnce(ctx, msg)
+		if err == nil || attempt >= c.retry.MaxAttempts || ctx.Err() != nil || !isRetryable(err) {
+			return result, err
+		}
+		if err := sleepContext(ctx, c.retry.backoff(attempt)); err != nil {
+			return result, err
+		}
+	}
+}
+
+// sendOnce 签名并发送一次请求，解析响应结果
+func (c *Client) sendOnce(ctx context.Context, msg *Msg) (*SendResult, error) {
+	// 在副本上签名，保证同一条消息可以被并发发送
+	signed := *msg
+	if err := signed.SetSign(c.secret); err != nil {
+		return nil, err
+	}
+
+	// 将消息内容转换为JSON格式
+	data, err := json.Marshal(&signed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	// 创建HTTP POST请求
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.hook, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for k, vs := range c.headers {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	// 发送请求
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	buf := new(bytes.Buffer)
+	_, err = buf.ReadFrom(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return parseResult(resp.StatusCode, buf.Bytes())
+}
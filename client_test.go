@@ -0,0 +1,110 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// 测试客户端发送请求：请求头、签名与响应内容
+func TestClientSend(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type 不正确: %s", r.Header.Get("Content-Type"))
+		}
+		if r.Header.Get("User-Agent") != "test-agent" {
+			t.Errorf("User-Agent 不正确: %s", r.Header.Get("User-Agent"))
+		}
+		if r.Header.Get("X-Env") != "prod" {
+			t.Errorf("基础请求头不正确: %s", r.Header.Get("X-Env"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success","data":{}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithHook(server.URL),
+		WithSecret("demo_secret"),
+		WithUserAgent("test-agent"),
+		WithHeader("X-Env", "prod"),
+	)
+
+	f := &FeishuMsg{Title: "测试客户端", Note: "客户端"}
+	if _, err := client.Send(context.Background(), f); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+
+	if got["msg_type"] != "interactive" {
+		t.Errorf("msg_type 不正确: %v", got["msg_type"])
+	}
+	if got["sign"] == nil || got["timestamp"] == nil {
+		t.Error("请求体应该包含签名字段")
+	}
+	if f.Response != `{"code":0,"msg":"success","data":{}}` {
+		t.Errorf("响应内容不正确: %v", f.Response)
+	}
+}
+
+// 测试 SendMsg 不会修改传入的消息
+func TestClientSendMsgNoMutation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	msg := FormatMsg(&FeishuMsg{Title: "测试不修改消息"})
+	client := NewClient(WithHook(server.URL), WithSecret("demo_secret"))
+	if _, err := client.SendMsg(context.Background(), msg); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+	if msg.Sign != "" || msg.Timestamp != "" {
+		t.Error("SendMsg 不应该修改传入的消息")
+	}
+}
+
+// 测试 context 取消
+func TestClientContextCancel(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := NewClient(WithHook(server.URL))
+	if _, err := client.Send(ctx, &FeishuMsg{Title: "测试取消"}); err == nil {
+		t.Error("context 超时后应该返回错误")
+	}
+}
+
+// 测试 WithTimeout 不会修改传入的 http.Client
+func TestClientTimeoutCopiesHTTPClient(t *testing.T) {
+	hc := &http.Client{}
+	client := NewClient(WithHTTPClient(hc), WithTimeout(time.Second))
+	if hc.Timeout != 0 {
+		t.Error("不应该修改传入的 http.Client")
+	}
+	if client.httpClient.Timeout != time.Second {
+		t.Errorf("超时时间不正确: %v", client.httpClient.Timeout)
+	}
+}
+
+// 测试 hook 为空
+func TestClientEmptyHook(t *testing.T) {
+	if err := SendFeishuMsg("", &FeishuMsg{Title: "空hook"}); err == nil {
+		t.Error("hook 为空时应该返回错误")
+	}
+}
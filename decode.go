@@ -0,0 +1,807 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/**
+ * @Description: 卡片 JSON 解码
+ * 按 tag 将组件解码为对应的类型，未识别的组件解码为 RawElement，
+ * 组件、卡片、正文、列以及文本、图标、选项等嵌套结构中未建模的字段保存在 Unknown 中，重新序列化时原样输出，
+ * 可以加载卡片搭建工具（https://open.feishu.cn/cardkit）导出的 JSON，修改后再发送
+ */
+
+// RawElement 未识别的组件，序列化时原样输出
+type RawElement struct {
+	Tag string
+	Raw json.RawMessage
+}
+
+// ElementTag 实现 CardElement
+func (e RawElement) ElementTag() string { return e.Tag }
+
+// MarshalJSON 原样输出
+func (e RawElement) MarshalJSON() ([]byte, error) {
+	if len(e.Raw) == 0 {
+		return json.Marshal(map[string]string{"tag": e.Tag})
+	}
+	return e.Raw, nil
+}
+
+// ParseCard 解码卡片 JSON，支持卡片 JSON 1.0 和 2.0 结构
+func ParseCard(data []byte) (*Card, error) {
+	var card Card
+	if err := json.Unmarshal(data, &card); err != nil {
+		return nil, fmt.Errorf("failed to parse card: %w", err)
+	}
+	return &card, nil
+}
+
+// NewCardMsg 构建一条消息卡片
+func NewCardMsg(card *Card) *Msg {
+	return &Msg{
+		MsgType: MsgTypeInteractive,
+		Card:    *card,
+	}
+}
+
+// UnmarshalCardElement 按 tag 将组件 JSON 解码为对应的类型
+func UnmarshalCardElement(data []byte) (CardElement, error) {
+	var head struct {
+		Tag string `json:"tag"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+
+	var elem CardElement
+	switch head.Tag {
+	case "markdown":
+		elem = &MarkdownElement{}
+	case "div":
+		elem = &DivElement{}
+	case "img":
+		elem = &ImageElement{}
+	case "column_set":
+		elem = &ColumnSetElement{}
+	case "hr":
+		elem = &HrElement{}
+	case "note":
+		elem = &NoteElement{}
+	case "action":
+		elem = &ActionElement{}
+	case "button":
+		elem = &ButtonElement{}
+	case "table":
+		elem = &TableElement{}
+	case "form":
+		elem = &FormElement{}
+	case "chart":
+		elem = &ChartElement{}
+	case "collapsible_panel":
+		elem = &CollapsiblePanelElement{}
+	case "input":
+		elem = &InputElement{}
+	case "select_static":
+		elem = &SelectStaticElement{}
+	case "multi_select_static":
+		elem = &MultiSelectStaticElement{}
+	case "date_picker":
+		elem = &DatePickerElement{}
+	case "picker_time":
+		elem = &TimePickerElement{}
+	case "checker":
+		elem = &CheckerElement{}
+	case "plain_text", "lark_md":
+		elem = &Text{}
+	default:
+		return RawElement{Tag: head.Tag, Raw: append(json.RawMessage(nil), data...)}, nil
+	}
+	if err := json.Unmarshal(data, elem); err != nil {
+		return nil, fmt.Errorf("failed to decode %s element: %w", head.Tag, err)
+	}
+	// 统一返回值类型，便于类型断言
+	return reflect.ValueOf(elem).Elem().Interface().(CardElement), nil
+}
+
+// decodeElements 解码组件列表
+func decodeElements(raws []json.RawMessage) ([]CardElement, error) {
+	if raws == nil {
+		return nil, nil
+	}
+	elements := make([]CardElement, 0, len(raws))
+	for _, raw := range raws {
+		elem, err := UnmarshalCardElement(raw)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, elem)
+	}
+	return elements, nil
+}
+
+// decodeKnown 解码到 v，返回 v 中没有对应字段的未知字段，ignore 中的字段不计入未知字段
+func decodeKnown(data []byte, v any, ignore ...string) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(v).Elem())
+	for k := range all {
+		if known[k] {
+			delete(all, k)
+		}
+	}
+	for _, k := range ignore {
+		delete(all, k)
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return all, nil
+}
+
+var fieldNamesCache sync.Map // reflect.Type -> map[string]bool
+
+// jsonFieldNames 返回结构体序列化时使用的字段名，包括嵌入结构体的字段
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	if names, ok := fieldNamesCache.Load(t); ok {
+		return names.(map[string]bool)
+	}
+
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for k := range jsonFieldNames(field.Type) {
+				names[k] = true
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	fieldNamesCache.Store(t, names)
+	return names
+}
+
+// appendUnknown 将未知字段按键排序后追加到 JSON 对象末尾
+func appendUnknown(data []byte, unknown map[string]json.RawMessage) ([]byte, error) {
+	if len(unknown) == 0 {
+		return data, nil
+	}
+	keys := make([]string, 0, len(unknown))
+	for k := range unknown {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for i, k := range keys {
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(unknown[k])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalKnown 序列化 v 并追加未知字段
+func marshalKnown(v any, unknown map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return appendUnknown(data, unknown)
+}
+
+// UnmarshalJSON 解码文本对象
+func (t *Text) UnmarshalJSON(data []byte) error {
+	type text Text
+	unknown, err := decodeKnown(data, (*text)(t))
+	t.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出文本对象
+func (t Text) MarshalJSON() ([]byte, error) {
+	type text Text
+	return marshalKnown(text(t), t.Unknown)
+}
+
+// UnmarshalJSON 解码图标
+func (i *Icon) UnmarshalJSON(data []byte) error {
+	type icon Icon
+	unknown, err := decodeKnown(data, (*icon)(i))
+	i.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出图标
+func (i Icon) MarshalJSON() ([]byte, error) {
+	type icon Icon
+	return marshalKnown(icon(i), i.Unknown)
+}
+
+// UnmarshalJSON 解码卡片链接
+func (c *CardLink) UnmarshalJSON(data []byte) error {
+	type cardLink CardLink
+	unknown, err := decodeKnown(data, (*cardLink)(c))
+	c.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出卡片链接
+func (c CardLink) MarshalJSON() ([]byte, error) {
+	type cardLink CardLink
+	return marshalKnown(cardLink(c), c.Unknown)
+}
+
+// UnmarshalJSON 解码国际化元素配置
+func (i *I18nElements) UnmarshalJSON(data []byte) error {
+	type i18nElements I18nElements
+	unknown, err := decodeKnown(data, (*i18nElements)(i))
+	i.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出国际化元素配置
+func (i I18nElements) MarshalJSON() ([]byte, error) {
+	type i18nElements I18nElements
+	return marshalKnown(i18nElements(i), i.Unknown)
+}
+
+// UnmarshalJSON 解码内边距
+func (p *Padding) UnmarshalJSON(data []byte) error {
+	type padding Padding
+	unknown, err := decodeKnown(data, (*padding)(p))
+	p.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出内边距
+func (p Padding) MarshalJSON() ([]byte, error) {
+	type padding Padding
+	return marshalKnown(padding(p), p.Unknown)
+}
+
+// UnmarshalJSON 解码二次确认弹窗
+func (c *Confirm) UnmarshalJSON(data []byte) error {
+	type confirm Confirm
+	unknown, err := decodeKnown(data, (*confirm)(c))
+	c.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出二次确认弹窗
+func (c Confirm) MarshalJSON() ([]byte, error) {
+	type confirm Confirm
+	return marshalKnown(confirm(c), c.Unknown)
+}
+
+// UnmarshalJSON 解码下拉选项
+func (o *Option) UnmarshalJSON(data []byte) error {
+	type option Option
+	unknown, err := decodeKnown(data, (*option)(o))
+	o.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出下拉选项
+func (o Option) MarshalJSON() ([]byte, error) {
+	type option Option
+	return marshalKnown(option(o), o.Unknown)
+}
+
+// UnmarshalJSON 解码div 字段
+func (f *Field) UnmarshalJSON(data []byte) error {
+	type field Field
+	unknown, err := decodeKnown(data, (*field)(f))
+	f.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出div 字段
+func (f Field) MarshalJSON() ([]byte, error) {
+	type field Field
+	return marshalKnown(field(f), f.Unknown)
+}
+
+// UnmarshalJSON 解码卡片摘要
+func (s *Summary) UnmarshalJSON(data []byte) error {
+	type summary Summary
+	unknown, err := decodeKnown(data, (*summary)(s))
+	s.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出卡片摘要
+func (s Summary) MarshalJSON() ([]byte, error) {
+	type summary Summary
+	return marshalKnown(summary(s), s.Unknown)
+}
+
+// UnmarshalJSON 解码交互行为
+func (b *Behavior) UnmarshalJSON(data []byte) error {
+	type behavior Behavior
+	unknown, err := decodeKnown(data, (*behavior)(b))
+	b.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出交互行为
+func (b Behavior) MarshalJSON() ([]byte, error) {
+	type behavior Behavior
+	return marshalKnown(behavior(b), b.Unknown)
+}
+
+// UnmarshalJSON 解码表头样式
+func (t *TableHeaderStyle) UnmarshalJSON(data []byte) error {
+	type tableHeaderStyle TableHeaderStyle
+	unknown, err := decodeKnown(data, (*tableHeaderStyle)(t))
+	t.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出表头样式
+func (t TableHeaderStyle) MarshalJSON() ([]byte, error) {
+	type tableHeaderStyle TableHeaderStyle
+	return marshalKnown(tableHeaderStyle(t), t.Unknown)
+}
+
+// UnmarshalJSON 解码表格列定义
+func (t *TableColumn) UnmarshalJSON(data []byte) error {
+	type tableColumn TableColumn
+	unknown, err := decodeKnown(data, (*tableColumn)(t))
+	t.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出表格列定义
+func (t TableColumn) MarshalJSON() ([]byte, error) {
+	type tableColumn TableColumn
+	return marshalKnown(tableColumn(t), t.Unknown)
+}
+
+// UnmarshalJSON 解码折叠面板标题栏
+func (c *CollapsiblePanelHeader) UnmarshalJSON(data []byte) error {
+	type collapsiblePanelHeader CollapsiblePanelHeader
+	unknown, err := decodeKnown(data, (*collapsiblePanelHeader)(c))
+	c.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出折叠面板标题栏
+func (c CollapsiblePanelHeader) MarshalJSON() ([]byte, error) {
+	type collapsiblePanelHeader CollapsiblePanelHeader
+	return marshalKnown(collapsiblePanelHeader(c), c.Unknown)
+}
+
+// UnmarshalJSON 解码折叠面板边框
+func (p *PanelBorder) UnmarshalJSON(data []byte) error {
+	type panelBorder PanelBorder
+	unknown, err := decodeKnown(data, (*panelBorder)(p))
+	p.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出折叠面板边框
+func (p PanelBorder) MarshalJSON() ([]byte, error) {
+	type panelBorder PanelBorder
+	return marshalKnown(panelBorder(p), p.Unknown)
+}
+
+// UnmarshalJSON 解码数字列格式
+func (t *TableNumberFormat) UnmarshalJSON(data []byte) error {
+	type tableNumberFormat TableNumberFormat
+	unknown, err := decodeKnown(data, (*tableNumberFormat)(t))
+	t.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出数字列格式
+func (t TableNumberFormat) MarshalJSON() ([]byte, error) {
+	type tableNumberFormat TableNumberFormat
+	return marshalKnown(tableNumberFormat(t), t.Unknown)
+}
+
+// UnmarshalJSON 解码卡片
+func (c *Card) UnmarshalJSON(data []byte) error {
+	type card Card
+	var v struct {
+		card
+		Elements []json.RawMessage `json:"elements"`
+	}
+	unknown, err := decodeKnown(data, &v)
+	if err != nil {
+		return err
+	}
+	elements, err := decodeElements(v.Elements)
+	if err != nil {
+		return err
+	}
+	*c = Card(v.card)
+	c.Elements = elements
+	c.Unknown = unknown
+	return nil
+}
+
+// UnmarshalJSON 解码卡片正文
+func (b *CardBody) UnmarshalJSON(data []byte) error {
+	type body CardBody
+	var v struct {
+		body
+		Elements []json.RawMessage `json:"elements"`
+	}
+	unknown, err := decodeKnown(data, &v)
+	if err != nil {
+		return err
+	}
+	elements, err := decodeElements(v.Elements)
+	if err != nil {
+		return err
+	}
+	*b = CardBody(v.body)
+	b.Elements = elements
+	b.Unknown = unknown
+	return nil
+}
+
+// UnmarshalJSON 解码单个语言的元素配置
+// 支持飞书文档中的组件数组形式（"zh_cn": [...]）和对象形式（"zh_cn": {"elements": [...]}）
+func (i *I18nElement) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var raws []json.RawMessage
+		if err := json.Unmarshal(trimmed, &raws); err != nil {
+			return err
+		}
+		elements, err := decodeElements(raws)
+		if err != nil {
+			return err
+		}
+		*i = I18nElement{Elements: elements, arrayForm: true}
+		return nil
+	}
+
+	type i18nElement I18nElement
+	var v struct {
+		i18nElement
+		Elements []json.RawMessage `json:"elements"`
+	}
+	unknown, err := decodeKnown(data, &v)
+	if err != nil {
+		return err
+	}
+	elements, err := decodeElements(v.Elements)
+	if err != nil {
+		return err
+	}
+	*i = I18nElement(v.i18nElement)
+	i.Elements = elements
+	i.Unknown = unknown
+	return nil
+}
+
+// MarshalJSON 输出单个语言的元素配置，按解码时的形式输出
+func (i I18nElement) MarshalJSON() ([]byte, error) {
+	if i.arrayForm && i.Header == nil && len(i.Unknown) == 0 {
+		if i.Elements == nil {
+			return []byte("[]"), nil
+		}
+		return json.Marshal(i.Elements)
+	}
+	type i18nElement I18nElement
+	return marshalKnown(i18nElement(i), i.Unknown)
+}
+
+// UnmarshalJSON 解码列
+func (c *Column) UnmarshalJSON(data []byte) error {
+	type column Column
+	var v struct {
+		column
+		Elements []json.RawMessage `json:"elements"`
+	}
+	unknown, err := decodeKnown(data, &v)
+	if err != nil {
+		return err
+	}
+	elements, err := decodeElements(v.Elements)
+	if err != nil {
+		return err
+	}
+	*c = Column(v.column)
+	c.Elements = elements
+	c.Unknown = unknown
+	return nil
+}
+
+// MarshalJSON 输出列，Elements 为空时输出 []
+func (c Column) MarshalJSON() ([]byte, error) {
+	type column Column
+	if c.Elements == nil {
+		c.Elements = []CardElement{}
+	}
+	data, err := json.Marshal(column(c))
+	if err != nil {
+		return nil, err
+	}
+	return appendUnknown(data, c.Unknown)
+}
+
+// UnmarshalJSON 解码卡片头部
+func (h *Header) UnmarshalJSON(data []byte) error {
+	type header Header
+	unknown, err := decodeKnown(data, (*header)(h))
+	h.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出卡片头部
+func (h Header) MarshalJSON() ([]byte, error) {
+	type header Header
+	data, err := json.Marshal(header(h))
+	if err != nil {
+		return nil, err
+	}
+	return appendUnknown(data, h.Unknown)
+}
+
+// isZero 是否未设置任何头部内容
+func (h Header) isZero() bool {
+	return h.Title.Content == "" && h.Title.Tag == "" && len(h.Title.Unknown) == 0 && h.Subtitle == nil && h.Template == "" &&
+		h.UdIcon == nil && h.Padding == "" && len(h.Unknown) == 0
+}
+
+// UnmarshalJSON 解码卡片全局配置
+func (c *Config) UnmarshalJSON(data []byte) error {
+	type config Config
+	unknown, err := decodeKnown(data, (*config)(c))
+	c.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出卡片全局配置
+func (c Config) MarshalJSON() ([]byte, error) {
+	type config Config
+	data, err := json.Marshal(config(c))
+	if err != nil {
+		return nil, err
+	}
+	return appendUnknown(data, c.Unknown)
+}
+
+// UnmarshalJSON 解码交互组件
+func (a *Action) UnmarshalJSON(data []byte) error {
+	type action Action
+	unknown, err := decodeKnown(data, (*action)(a))
+	a.Unknown = unknown
+	return err
+}
+
+// MarshalJSON 输出交互组件
+func (a Action) MarshalJSON() ([]byte, error) {
+	type action Action
+	data, err := json.Marshal(action(a))
+	if err != nil {
+		return nil, err
+	}
+	return appendUnknown(data, a.Unknown)
+}
+
+// UnmarshalJSON 解码组件
+func (e *MarkdownElement) UnmarshalJSON(data []byte) error {
+	type element MarkdownElement
+	unknown, err := decodeKnown(data, (*element)(e), "tag")
+	e.Unknown = unknown
+	return err
+}
+
+// UnmarshalJSON 解码组件，附加组件按 tag 解码
+func (e *DivElement) UnmarshalJSON(data []byte) error {
+	type element DivElement
+	var v struct {
+		element
+		Extra json.RawMessage `json:"extra"`
+	}
+	unknown, err := decodeKnown(data, &v, "tag")
+	if err != nil {
+		return err
+	}
+	*e = DivElement(v.element)
+	if len(v.Extra) > 0 && string(v.Extra) != "null" {
+		if e.Extra, err = UnmarshalCardElement(v.Extra); err != nil {
+			return err
+		}
+	}
+	e.Unknown = unknown
+	return nil
+}
+
+// UnmarshalJSON 解码组件
+func (e *ImageElement) UnmarshalJSON(data []byte) error {
+	type element ImageElement
+	unknown, err := decodeKnown(data, (*element)(e), "tag")
+	e.Unknown = unknown
+	return err
+}
+
+// UnmarshalJSON 解码组件
+func (e *ColumnSetElement) UnmarshalJSON(data []byte) error {
+	type element ColumnSetElement
+	unknown, err := decodeKnown(data, (*element)(e), "tag")
+	e.Unknown = unknown
+	return err
+}
+
+// UnmarshalJSON 解码组件
+func (e *HrElement) UnmarshalJSON(data []byte) error {
+	type element HrElement
+	unknown, err := decodeKnown(data, (*element)(e), "tag")
+	e.Unknown = unknown
+	return err
+}
+
+// UnmarshalJSON 解码组件，子元素按 tag 解码
+func (e *NoteElement) UnmarshalJSON(data []byte) error {
+	type element NoteElement
+	var v struct {
+		element
+		Elements []json.RawMessage `json:"elements"`
+	}
+	unknown, err := decodeKnown(data, &v, "tag")
+	if err != nil {
+		return err
+	}
+	*e = NoteElement(v.element)
+	if e.Elements, err = decodeElements(v.Elements); err != nil {
+		return err
+	}
+	e.Unknown = unknown
+	return nil
+}
+
+// UnmarshalJSON 解码组件
+func (e *ActionElement) UnmarshalJSON(data []byte) error {
+	type element ActionElement
+	unknown, err := decodeKnown(data, (*element)(e), "tag")
+	e.Unknown = unknown
+	return err
+}
+
+// UnmarshalJSON 解码组件
+func (e *ButtonElement) UnmarshalJSON(data []byte) error {
+	type element ButtonElement
+	unknown, err := decodeKnown(data, (*element)(e), "tag")
+	e.Unknown = unknown
+	return err
+}
+
+// UnmarshalJSON 解码组件
+func (e *TableElement) UnmarshalJSON(data []byte) error {
+	type element TableElement
+	unknown, err := decodeKnown(data, (*element)(e), "tag")
+	e.Unknown = unknown
+	return err
+}
+
+// UnmarshalJSON 解码组件
+func (e *ChartElement) UnmarshalJSON(data []byte) error {
+	type element ChartElement
+	unknown, err := decodeKnown(data, (*element)(e), "tag")
+	e.Unknown = unknown
+	return err
+}
+
+// UnmarshalJSON 解码组件，子元素按 tag 解码
+func (e *CollapsiblePanelElement) UnmarshalJSON(data []byte) error {
+	type element CollapsiblePanelElement
+	var v struct {
+		element
+		Elements []json.RawMessage `json:"elements"`
+	}
+	unknown, err := decodeKnown(data, &v, "tag")
+	if err != nil {
+		return err
+	}
+	*e = CollapsiblePanelElement(v.element)
+	if e.Elements, err = decodeElements(v.Elements); err != nil {
+		return err
+	}
+	e.Unknown = unknown
+	return nil
+}
+
+// UnmarshalJSON 解码组件，子元素按 tag 解码
+func (e *FormElement) UnmarshalJSON(data []byte) error {
+	type element FormElement
+	var v struct {
+		element
+		Elements []json.RawMessage `json:"elements"`
+	}
+	unknown, err := decodeKnown(data, &v, "tag")
+	if err != nil {
+		return err
+	}
+	*e = FormElement(v.element)
+	if e.Elements, err = decodeElements(v.Elements); err != nil {
+		return err
+	}
+	e.Unknown = unknown
+	return nil
+}
+
+// UnmarshalJSON 解码组件
+func (e *InputElement) UnmarshalJSON(data []byte) error {
+	type element InputElement
+	unknown, err := decodeKnown(data, (*element)(e), "tag")
+	e.Unknown = unknown
+	return err
+}
+
+// UnmarshalJSON 解码组件
+func (e *SelectStaticElement) UnmarshalJSON(data []byte) error {
+	type element SelectStaticElement
+	unknown, err := decodeKnown(data, (*element)(e), "tag")
+	e.Unknown = unknown
+	return err
+}
+
+// UnmarshalJSON 解码组件
+func (e *MultiSelectStaticElement) UnmarshalJSON(data []byte) error {
+	type element MultiSelectStaticElement
+	unknown, err := decodeKnown(data, (*element)(e), "tag")
+	e.Unknown = unknown
+	return err
+}
+
+// UnmarshalJSON 解码组件
+func (e *DatePickerElement) UnmarshalJSON(data []byte) error {
+	type element DatePickerElement
+	unknown, err := decodeKnown(data, (*element)(e), "tag")
+	e.Unknown = unknown
+	return err
+}
+
+// UnmarshalJSON 解码组件
+func (e *TimePickerElement) UnmarshalJSON(data []byte) error {
+	type element TimePickerElement
+	unknown, err := decodeKnown(data, (*element)(e), "tag")
+	e.Unknown = unknown
+	return err
+}
+
+// UnmarshalJSON 解码组件
+func (e *CheckerElement) UnmarshalJSON(data []byte) error {
+	type element CheckerElement
+	unknown, err := decodeKnown(data, (*element)(e), "tag")
+	e.Unknown = unknown
+	return err
+}
@@ -0,0 +1,179 @@
+package bot
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// assertJSONEqual 比较两段 JSON 的内容，忽略字段顺序与空白
+func assertJSONEqual(t *testing.T, expected, actual []byte) {
+	t.Helper()
+	var e, a any
+	if err := json.Unmarshal(expected, &e); err != nil {
+		t.Fatalf("解析期望值失败: %v", err)
+	}
+	if err := json.Unmarshal(actual, &a); err != nil {
+		t.Fatalf("解析实际值失败: %v", err)
+	}
+	if !reflect.DeepEqual(e, a) {
+		t.Errorf("JSON 内容不一致:\n期望 %s\n实际 %s", expected, actual)
+	}
+}
+
+// 测试示例卡片解码后重新序列化不丢失字段
+func TestParseCardRoundTrip(t *testing.T) {
+	files, _ := filepath.Glob("testdata/cards/*.json")
+	if len(files) == 0 {
+		t.Fatal("没有找到示例卡片")
+	}
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			card, err := ParseCard(data)
+			if err != nil {
+				t.Fatalf("解码失败: %v", err)
+			}
+			out, err := json.Marshal(card)
+			if err != nil {
+				t.Fatalf("序列化失败: %v", err)
+			}
+			assertJSONEqual(t, data, out)
+		})
+	}
+}
+
+// 测试按 tag 解码为对应的组件类型
+func TestParseCardTypes(t *testing.T) {
+	data, _ := os.ReadFile("testdata/cards/v1_alert.json")
+	card, err := ParseCard(data)
+	if err != nil {
+		t.Fatalf("解码失败: %v", err)
+	}
+
+	expected := []CardElement{MarkdownElement{}, DivElement{}, HrElement{}, ColumnSetElement{}, ActionElement{}, RawElement{}, NoteElement{}}
+	if len(card.Elements) != len(expected) {
+		t.Fatalf("组件数量不正确: %d", len(card.Elements))
+	}
+	for i, e := range expected {
+		if reflect.TypeOf(card.Elements[i]) != reflect.TypeOf(e) {
+			t.Errorf("第 %d 个组件类型不正确: 期望 %T, 实际 %T", i, e, card.Elements[i])
+		}
+	}
+
+	div := card.Elements[1].(DivElement)
+	if img, ok := div.Extra.(ImageElement); !ok || img.ImgKey != "img_v3_xxx" {
+		t.Errorf("附加组件解码不正确: %#v", div.Extra)
+	}
+	if raw := card.Elements[5].(RawElement); raw.Tag != "person" {
+		t.Errorf("未识别组件的 tag 不正确: %s", raw.Tag)
+	}
+	if _, ok := card.Header.Unknown["text_tag_list"]; !ok {
+		t.Error("头部未知字段应该保留")
+	}
+	if _, ok := card.Elements[4].(ActionElement).Actions[0].Unknown["multi_url"]; !ok {
+		t.Error("按钮未知字段应该保留")
+	}
+}
+
+// 测试加载卡片后修改并发送
+func TestParseCardModify(t *testing.T) {
+	data, _ := os.ReadFile("testdata/cards/v2_cardkit.json")
+	card, err := ParseCard(data)
+	if err != nil {
+		t.Fatalf("解码失败: %v", err)
+	}
+
+	card.Header.Title.Content = "发布审批（已更新）"
+	md := card.Body.Elements[0].(MarkdownElement)
+	md.Content = "请审批 v1.2.1"
+	card.Body.Elements[0] = md
+
+	out, _ := json.Marshal(NewCardMsg(card))
+	var got struct {
+		MsgType string `json:"msg_type"`
+		Card    struct {
+			Schema string `json:"schema"`
+			Header struct {
+				Title Text           `json:"title"`
+				Icon  map[string]any `json:"icon"`
+			} `json:"header"`
+			Body struct {
+				Elements []map[string]any `json:"elements"`
+			} `json:"body"`
+		} `json:"card"`
+	}
+	_ = json.Unmarshal(out, &got)
+	if got.MsgType != MsgTypeInteractive || got.Card.Schema != "2.0" {
+		t.Errorf("消息结构不正确: %s", out)
+	}
+	if got.Card.Header.Title.Content != "发布审批（已更新）" || got.Card.Header.Icon["token"] != "approval_colorful" {
+		t.Errorf("头部不正确: %+v", got.Card.Header)
+	}
+	first := got.Card.Body.Elements[0]
+	if first["content"] != "请审批 v1.2.1" || first["element_id"] != "md_1" || first["text_size"] != "normal_v2" {
+		t.Errorf("修改后的组件不正确: %v", first)
+	}
+}
+
+// 测试 FormatMsg 生成的卡片可以解码（发件箱依赖）
+func TestFormatMsgRoundTrip(t *testing.T) {
+	for _, schema := range []CardSchema{CardSchemaV1, CardSchemaV2} {
+		msg := FormatMsg(&FeishuMsg{
+			Title:         "标题",
+			MarkdownArray: [][2]string{{"状态", "成功"}},
+			Actions:       []Action{CreateButtonElement("查看", "https://example.com")},
+			Note:          "备注",
+			Schema:        schema,
+		})
+		data, _ := json.Marshal(msg)
+
+		var decoded Msg
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("反序列化失败: %v", err)
+		}
+		again, _ := json.Marshal(decoded)
+		assertJSONEqual(t, data, again)
+	}
+}
+
+// 测试组件解码错误
+func TestUnmarshalCardElementError(t *testing.T) {
+	if _, err := UnmarshalCardElement([]byte(`{"tag":"markdown","content":1}`)); err == nil {
+		t.Error("字段类型错误应该返回错误")
+	}
+	if _, err := ParseCard([]byte(`{"elements":[1]}`)); err == nil {
+		t.Error("组件不是对象应该返回错误")
+	}
+}
+
+// 测试组件数组形式的国际化元素和嵌套结构中的未知字段
+func TestParseCardNested(t *testing.T) {
+	data, _ := os.ReadFile("testdata/cards/v1_i18n.json")
+	card, err := ParseCard(data)
+	if err != nil {
+		t.Fatalf("解码失败: %v", err)
+	}
+	zh := card.I18nElements.ZhCn
+	if zh == nil || len(zh.Elements) != 4 || card.I18nElements.EnUs == nil {
+		t.Fatalf("国际化元素解码不正确: %+v", card.I18nElements)
+	}
+	if _, ok := card.I18nElements.Unknown["zh_hk"]; !ok {
+		t.Error("未建模的语言应该保留")
+	}
+	div := zh.Elements[0].(DivElement)
+	if _, ok := div.Text.Unknown["text_size"]; !ok {
+		t.Errorf("文本对象的未知字段应该保留: %+v", div.Text)
+	}
+	if _, ok := card.Unknown["i18n_header"]; !ok {
+		t.Error("i18n_header 应该保留")
+	}
+	if err := Validate(NewCardMsg(card)); err != nil {
+		t.Errorf("校验失败: %v", err)
+	}
+}
@@ -0,0 +1,409 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+/**
+ * @Description: 异步发送队列
+ * 消息在入队时通过 FormatMsg 格式化，由固定数量的 worker 通过 Client 发送，
+ * 避免飞书响应缓慢时阻塞调用方。Close 会停止接收新消息并等待队列中的消息发送完成。
+ * 配置 Outbox 后，消息入队前先持久化，发送成功后确认；启动后在后台重新发送上次未确认的消息，
+ * 可重试的错误（超时、5xx、频率限制等）保留在发件箱中，每隔 OutboxRetryInterval 重新入队，不需要等到下次启动；
+ * 不可重试的错误（签名、关键词校验失败等）或失败次数达到 OutboxMaxAttempts 时从发件箱中移除，并通过 OnFailure 报告。
+ * 发件箱中的消息只在最终发送成功或放弃时回调一次。
+ */
+
+const (
+	// DefaultOutboxRetryInterval 默认重新发送发件箱中未确认消息的间隔
+	DefaultOutboxRetryInterval = 30 * time.Second
+	// DefaultOutboxMaxAttempts 发件箱中每条消息默认的最大发送次数
+	DefaultOutboxMaxAttempts = 5
+)
+
+var (
+	// ErrQueueFull 队列已满（丢弃策略）
+	ErrQueueFull = errors.New("dispatcher queue is full")
+	// ErrDispatcherClosed 发送队列已关闭
+	ErrDispatcherClosed = errors.New("dispatcher is closed")
+	// ErrOutboxMaxAttempts 发件箱中的消息发送失败次数达到上限，已放弃发送
+	ErrOutboxMaxAttempts = errors.New("outbox message reached max attempts")
+)
+
+// FullPolicy 队列已满时的处理策略
+type FullPolicy int
+
+const (
+	FullPolicyBlock FullPolicy = iota // 阻塞等待队列空闲（默认）
+	FullPolicyDrop                    // 直接丢弃并返回 ErrQueueFull
+)
+
+// Callbacks 单条消息的发送回调，在 worker goroutine 中执行
+type Callbacks struct {
+	OnSuccess func(result *SendResult)
+	OnFailure func(err error)
+}
+
+// job 队列中的一条消息
+type job struct {
+	msg       *Msg
+	callbacks Callbacks
+	seq       uint64 // 发件箱中的序号，0 表示未持久化
+}
+
+// Dispatcher 异步发送队列
+type Dispatcher struct {
+	client     *Client
+	queueSize  int
+	workers    int
+	fullPolicy FullPolicy
+	outbox     *Outbox
+
+	outboxCallbacks Callbacks            // 从发件箱重新发送的消息使用的回调
+	retryInterval   time.Duration        // 重新发送发件箱中未确认消息的间隔
+	maxAttempts     int                  // 发件箱中每条消息的最大发送次数
+	inflightMu      sync.Mutex           // 保护 inflight 和 callbacks，获取顺序在 Outbox 的锁之前
+	inflight        map[uint64]bool      // 已在队列中或正在发送的发件箱消息
+	callbacks       map[uint64]Callbacks // 本进程入队的发件箱消息的回调，重试时继续使用
+	replayed        chan struct{}        // 启动时的未确认消息已全部入队
+
+	queue   chan job
+	closing chan struct{}
+	mu      sync.RWMutex
+	closed  bool
+	once    sync.Once
+	wg      sync.WaitGroup
+
+	ctx    context.Context // worker 发送使用的 context，Close 超时后取消
+	cancel context.CancelFunc
+}
+
+// DispatcherOption 发送队列配置项
+type DispatcherOption func(*Dispatcher)
+
+// WithQueueSize 设置队列长度，默认 100
+func WithQueueSize(n int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.queueSize = n
+	}
+}
+
+// WithWorkers 设置 worker 数量，默认 1
+func WithWorkers(n int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.workers = n
+	}
+}
+
+// WithFullPolicy 设置队列已满时的处理策略
+func WithFullPolicy(p FullPolicy) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.fullPolicy = p
+	}
+}
+
+// WithOutbox 使用本地发件箱持久化消息，启动时重新发送未确认的消息
+func WithOutbox(o *Outbox) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.outbox = o
+	}
+}
+
+// WithOutboxRetryInterval 设置重新发送发件箱中未确认消息的间隔，默认 DefaultOutboxRetryInterval
+func WithOutboxRetryInterval(interval time.Duration) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.retryInterval = interval
+	}
+}
+
+// WithOutboxMaxAttempts 设置发件箱中每条消息的最大发送次数，默认 DefaultOutboxMaxAttempts
+func WithOutboxMaxAttempts(n int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.maxAttempts = n
+	}
+}
+
+// WithOutboxCallbacks 设置启动时从发件箱重新发送的消息的回调
+func WithOutboxCallbacks(callbacks Callbacks) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.outboxCallbacks = callbacks
+	}
+}
+
+// NewDispatcher 创建异步发送队列并启动 worker
+func NewDispatcher(client *Client, opts ...DispatcherOption) *Dispatcher {
+	d := &Dispatcher{
+		client:        client,
+		queueSize:     100,
+		workers:       1,
+		closing:       make(chan struct{}),
+		retryInterval: DefaultOutboxRetryInterval,
+		maxAttempts:   DefaultOutboxMaxAttempts,
+		inflight:      make(map[uint64]bool),
+		callbacks:     make(map[uint64]Callbacks),
+		replayed:      make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.queueSize < 0 {
+		d.queueSize = 0
+	}
+	if d.workers <= 0 {
+		d.workers = 1
+	}
+	if d.retryInterval <= 0 {
+		d.retryInterval = DefaultOutboxRetryInterval
+	}
+	if d.maxAttempts <= 0 {
+		d.maxAttempts = DefaultOutboxMaxAttempts
+	}
+
+	d.queue = make(chan job, d.queueSize)
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	for i := 0; i < d.workers; i++ {
+		d.wg.Add(1)
+		go d.work()
+	}
+
+	// 在后台重新发送未确认的消息，不阻塞调用方
+	if d.outbox != nil {
+		d.wg.Add(1)
+		go d.retryOutbox()
+	} else {
+		close(d.replayed)
+	}
+	return d
+}
+
+// Enqueue 格式化 FeishuMsg 并加入发送队列
+func (d *Dispatcher) Enqueue(ctx context.Context, f *FeishuMsg, callbacks Callbacks) error {
+	return d.EnqueueMsg(ctx, d.client.format(f), callbacks)
+}
+
+// EnqueueMsg 将已构建好的消息加入发送队列
+func (d *Dispatcher) EnqueueMsg(ctx context.Context, msg *Msg, callbacks Callbacks) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
+		return ErrDispatcherClosed
+	}
+
+	j := job{msg: msg, callbacks: callbacks}
+	if d.outbox != nil {
+		d.inflightMu.Lock()
+		seq, err := d.outbox.Put(msg)
+		if err == nil {
+			d.inflight[seq] = true
+			d.callbacks[seq] = callbacks
+		}
+		d.inflightMu.Unlock()
+		if err != nil {
+			return err
+		}
+		j.seq = seq
+	}
+
+	if d.fullPolicy == FullPolicyDrop {
+		select {
+		case d.queue <- j:
+			return nil
+		default:
+			d.discard(j)
+			return ErrQueueFull
+		}
+	}
+
+	select {
+	case d.queue <- j:
+		return nil
+	case <-ctx.Done():
+		d.discard(j)
+		return ctx.Err()
+	case <-d.closing:
+		d.discard(j)
+		return ErrDispatcherClosed
+	}
+}
+
+// discard 未能入队的消息从发件箱中移除
+func (d *Dispatcher) discard(j job) {
+	if j.seq > 0 {
+		_ = d.outbox.Ack(j.seq)
+		d.release(j.seq)
+		d.forget(j.seq)
+	}
+}
+
+// release 发件箱消息发送结束（成功或失败），失败的消息可以再次入队
+func (d *Dispatcher) release(seq uint64) {
+	d.inflightMu.Lock()
+	delete(d.inflight, seq)
+	d.inflightMu.Unlock()
+}
+
+// forget 发件箱消息已确认，不再需要保留回调
+func (d *Dispatcher) forget(seq uint64) {
+	d.inflightMu.Lock()
+	delete(d.callbacks, seq)
+	d.inflightMu.Unlock()
+}
+
+// claimPending 返回发件箱中不在队列中的未确认消息，并标记为已入队
+// 本进程入队的消息使用原来的回调，其余消息使用 outboxCallbacks
+func (d *Dispatcher) claimPending() []job {
+	d.inflightMu.Lock()
+	defer d.inflightMu.Unlock()
+
+	var jobs []job
+	for _, entry := range d.outbox.Pending() {
+		if d.inflight[entry.Seq] {
+			continue
+		}
+		d.inflight[entry.Seq] = true
+		callbacks, ok := d.callbacks[entry.Seq]
+		if !ok {
+			callbacks = d.outboxCallbacks
+		}
+		jobs = append(jobs, job{msg: entry.Msg, callbacks: callbacks, seq: entry.Seq})
+	}
+	return jobs
+}
+
+// retryOutbox 启动时重新发送未确认的消息，之后定期重新发送发送失败的消息
+func (d *Dispatcher) retryOutbox() {
+	defer d.wg.Done()
+	d.requeue()
+	close(d.replayed)
+
+	ticker := time.NewTicker(d.retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.closing:
+			return
+		case <-ticker.C:
+			d.requeue()
+		}
+	}
+}
+
+// requeue 将发件箱中未确认的消息加入队列，队列关闭时停止，剩余消息留在发件箱中
+func (d *Dispatcher) requeue() {
+	jobs := d.claimPending()
+	for i, j := range jobs {
+		if !d.push(j) {
+			for _, rest := range jobs[i:] {
+				d.release(rest.seq)
+			}
+			return
+		}
+	}
+}
+
+// push 阻塞地将消息加入队列，队列关闭时返回 false
+func (d *Dispatcher) push(j job) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
+		return false
+	}
+	select {
+	case d.queue <- j:
+		return true
+	case <-d.closing:
+		return false
+	}
+}
+
+// Close 停止接收新消息并等待队列中的消息发送完成
+// 启动时的未确认消息会先全部入队；ctx 超时后会取消正在进行的发送，剩余消息以错误回调结束
+func (d *Dispatcher) Close(ctx context.Context) error {
+	select {
+	case <-d.replayed:
+	case <-ctx.Done():
+	}
+
+	d.once.Do(func() {
+		close(d.closing)
+		d.mu.Lock()
+		d.closed = true
+		close(d.queue)
+		d.mu.Unlock()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		d.cancel()
+		return nil
+	case <-ctx.Done():
+		d.cancel()
+		return ctx.Err()
+	}
+}
+
+// work 从队列中取出消息并发送
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+	for j := range d.queue {
+		result, err := d.client.SendMsg(d.ctx, j.msg)
+		if j.seq > 0 {
+			var retry bool
+			if retry, err = d.settle(j.seq, err); retry {
+				// 保留在发件箱中，由 retryOutbox 重新入队，最终结果再回调
+				d.release(j.seq)
+				continue
+			}
+			d.release(j.seq)
+			d.forget(j.seq)
+		}
+		if err != nil {
+			if j.callbacks.OnFailure != nil {
+				j.callbacks.OnFailure(err)
+			}
+			continue
+		}
+		if j.callbacks.OnSuccess != nil {
+			j.callbacks.OnSuccess(result)
+		}
+	}
+}
+
+// settle 根据发送结果处理发件箱中的消息：成功或放弃时确认，可重试时记录失败次数并保留
+// retry 为 true 表示消息保留在发件箱中等待重试，此时不回调
+func (d *Dispatcher) settle(seq uint64, err error) (retry bool, _ error) {
+	if err == nil {
+		return false, d.outbox.Ack(seq)
+	}
+	// Close 超时取消了发送，消息保留在发件箱中，下次启动时重新发送
+	if d.ctx.Err() != nil {
+		return false, err
+	}
+
+	if isRetryable(err) {
+		attempts, failErr := d.outbox.Fail(seq)
+		if failErr != nil {
+			return false, errors.Join(err, failErr)
+		}
+		if attempts < d.maxAttempts {
+			return true, nil
+		}
+		err = fmt.Errorf("%w (%d attempts): %w", ErrOutboxMaxAttempts, attempts, err)
+	}
+
+	// 不可重试或达到最大次数，放弃发送
+	if ackErr := d.outbox.Ack(seq); ackErr != nil {
+		return false, errors.Join(err, ackErr)
+	}
+	return false, err
+}
@@ -0,0 +1,141 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 测试队列消息全部发送，Close 会等待队列排空
+func TestDispatcherDrainOnClose(t *testing.T) {
+	var received int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&received, 1)
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(NewClient(WithHook(server.URL)), WithWorkers(3), WithQueueSize(20))
+
+	var succeeded int32
+	for i := 0; i < 20; i++ {
+		err := d.Enqueue(context.Background(), &FeishuMsg{Title: "测试异步发送"}, Callbacks{
+			OnSuccess: func(result *SendResult) {
+				atomic.AddInt32(&succeeded, 1)
+			},
+		})
+		if err != nil {
+			t.Fatalf("入队失败: %v", err)
+		}
+	}
+
+	if err := d.Close(context.Background()); err != nil {
+		t.Fatalf("关闭失败: %v", err)
+	}
+	if received != 20 || succeeded != 20 {
+		t.Errorf("应该发送 20 条消息，实际接收 %d 条，成功回调 %d 次", received, succeeded)
+	}
+
+	if err := d.Enqueue(context.Background(), &FeishuMsg{Title: "关闭后入队"}, Callbacks{}); !errors.Is(err, ErrDispatcherClosed) {
+		t.Errorf("关闭后入队应该返回 ErrDispatcherClosed，实际是 %v", err)
+	}
+}
+
+// 测试失败回调
+func TestDispatcherFailureCallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":19024,"msg":"Key Words Not Found"}`))
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(NewClient(WithHook(server.URL)))
+
+	errCh := make(chan error, 1)
+	err := d.Enqueue(context.Background(), &FeishuMsg{Title: "测试失败回调"}, Callbacks{
+		OnFailure: func(err error) {
+			errCh <- err
+		},
+	})
+	if err != nil {
+		t.Fatalf("入队失败: %v", err)
+	}
+
+	var apiErr *APIError
+	if err := <-errCh; !errors.As(err, &apiErr) {
+		t.Errorf("应该回调 *APIError，实际是 %v", err)
+	}
+	_ = d.Close(context.Background())
+}
+
+// 测试丢弃策略
+func TestDispatcherDropPolicy(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(NewClient(WithHook(server.URL)), WithQueueSize(1), WithFullPolicy(FullPolicyDrop))
+
+	// 第一条消息被 worker 取出并阻塞在发送中
+	_ = d.EnqueueMsg(context.Background(), FormatMsg(&FeishuMsg{Title: "1"}), Callbacks{})
+	deadline := time.Now().Add(time.Second)
+	for len(d.queue) > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	// 第二条消息占满队列，第三条被丢弃
+	if err := d.EnqueueMsg(context.Background(), FormatMsg(&FeishuMsg{Title: "2"}), Callbacks{}); err != nil {
+		t.Fatalf("第二条消息应该入队成功: %v", err)
+	}
+	if err := d.EnqueueMsg(context.Background(), FormatMsg(&FeishuMsg{Title: "3"}), Callbacks{}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("队列已满应该返回 ErrQueueFull，实际是 %v", err)
+	}
+
+	close(release)
+	if err := d.Close(context.Background()); err != nil {
+		t.Fatalf("关闭失败: %v", err)
+	}
+}
+
+// 测试 Close 超时后取消正在进行的发送
+func TestDispatcherCloseTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	d := NewDispatcher(NewClient(WithHook(server.URL)))
+	failed := make(chan error, 1)
+	_ = d.Enqueue(context.Background(), &FeishuMsg{Title: "测试关闭超时"}, Callbacks{
+		OnFailure: func(err error) {
+			failed <- err
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if err := d.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("关闭超时应该返回 context 错误，实际是 %v", err)
+	}
+
+	select {
+	case err := <-failed:
+		if err == nil {
+			t.Error("取消的发送应该回调错误")
+		}
+	case <-time.After(time.Second):
+		t.Error("Close 超时后正在进行的发送应该被取消")
+	}
+}
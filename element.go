@@ -0,0 +1,371 @@
+package bot
+
+import "encoding/json"
+
+/**
+ * @Description: 卡片组件
+ * 每种组件使用独立的类型，只包含该组件支持的字段，序列化时自动输出 tag
+ * 组件文档 https://open.feishu.cn/document/feishu-cards/card-components/component-overview
+ * 旧的 Element 结构同样实现了 CardElement，可以与新组件混合使用
+ */
+
+// CardElement 卡片组件，可以放入 Card.Elements、CardBody.Elements、Column.Elements 等容器
+type CardElement interface {
+	// ElementTag 返回组件的 tag
+	ElementTag() string
+}
+
+// ElementTag 旧的 Element 结构按 Tag 字段作为组件使用
+func (e Element) ElementTag() string { return e.Tag }
+
+// ElementTag 文本对象可以直接作为 note 组件的子元素
+func (t Text) ElementTag() string { return t.Tag }
+
+// MarkdownElement 富文本组件
+type MarkdownElement struct {
+	ElementID string `json:"element_id,omitempty"`
+	Content   string `json:"content"`
+	TextAlign string `json:"text_align,omitempty"` // left、center、right
+	TextSize  string `json:"text_size,omitempty"`  // normal、heading、notation 等
+	Icon      *Icon  `json:"icon,omitempty"`       // 前缀图标
+	Margin    string `json:"margin,omitempty"`     // 外边距（卡片2.0）
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (MarkdownElement) ElementTag() string { return "markdown" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e MarkdownElement) MarshalJSON() ([]byte, error) {
+	type element MarkdownElement
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// DivElement 内容模块，以文本为主体，可以附带并排字段和附加组件
+type DivElement struct {
+	ElementID string      `json:"element_id,omitempty"`
+	Text      *Text       `json:"text,omitempty"`   // 文本，tag 为 plain_text 或 lark_md
+	Fields    []Field     `json:"fields,omitempty"` // 并排字段
+	Extra     CardElement `json:"extra,omitempty"`  // 附加组件，如图片、按钮
+	TextAlign string      `json:"text_align,omitempty"`
+	Margin    string      `json:"margin,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (DivElement) ElementTag() string { return "div" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e DivElement) MarshalJSON() ([]byte, error) {
+	type element DivElement
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// ImageElement 图片组件
+type ImageElement struct {
+	ElementID    string `json:"element_id,omitempty"`
+	ImgKey       string `json:"img_key"`
+	Alt          *Text  `json:"alt,omitempty"`
+	Title        *Text  `json:"title,omitempty"`
+	Mode         string `json:"mode,omitempty"`         // crop_center、fit_horizontal、large、medium、small、tiny
+	CustomWidth  int    `json:"custom_width,omitempty"` // 自定义最大展示宽度（像素）
+	CompactWidth bool   `json:"compact_width,omitempty"`
+	Preview      *bool  `json:"preview,omitempty"` // 点击后是否放大图片，默认 true
+	Margin       string `json:"margin,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (ImageElement) ElementTag() string { return "img" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e ImageElement) MarshalJSON() ([]byte, error) {
+	type element ImageElement
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// ColumnSetElement 多列布局组件
+type ColumnSetElement struct {
+	ElementID         string   `json:"element_id,omitempty"`
+	FlexMode          string   `json:"flex_mode,omitempty"` // none、stretch、flow、bisect、trisect
+	BackgroundStyle   string   `json:"background_style,omitempty"`
+	HorizontalSpacing string   `json:"horizontal_spacing,omitempty"`
+	HorizontalAlign   string   `json:"horizontal_align,omitempty"`
+	Margin            string   `json:"margin,omitempty"`
+	Columns           []Column `json:"columns"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (ColumnSetElement) ElementTag() string { return "column_set" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e ColumnSetElement) MarshalJSON() ([]byte, error) {
+	type element ColumnSetElement
+	if e.Columns == nil {
+		e.Columns = []Column{}
+	}
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// HrElement 分割线组件
+type HrElement struct {
+	ElementID string `json:"element_id,omitempty"`
+	Margin    string `json:"margin,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (HrElement) ElementTag() string { return "hr" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e HrElement) MarshalJSON() ([]byte, error) {
+	type element HrElement
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// NoteElement 备注组件（仅卡片1.0），子元素可以是 Text 或 ImageElement
+type NoteElement struct {
+	ElementID string        `json:"element_id,omitempty"`
+	Elements  []CardElement `json:"elements"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (NoteElement) ElementTag() string { return "note" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e NoteElement) MarshalJSON() ([]byte, error) {
+	type element NoteElement
+	if e.Elements == nil {
+		e.Elements = []CardElement{}
+	}
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// ActionElement 交互模块（仅卡片1.0），横向排列按钮、下拉选择等交互组件
+type ActionElement struct {
+	ElementID string   `json:"element_id,omitempty"`
+	Layout    string   `json:"layout,omitempty"` // bisected、trisection、flow
+	Actions   []Action `json:"actions"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (ActionElement) ElementTag() string { return "action" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e ActionElement) MarshalJSON() ([]byte, error) {
+	type element ActionElement
+	if e.Actions == nil {
+		e.Actions = []Action{}
+	}
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// ButtonElement 按钮组件，卡片2.0中可以直接放在正文或列中
+type ButtonElement struct {
+	ElementID string     `json:"element_id,omitempty"`
+	Text      *Text      `json:"text,omitempty"`
+	Type      string     `json:"type,omitempty"`  // default、primary、danger、text 等
+	Size      string     `json:"size,omitempty"`  // tiny、small、medium、large
+	Width     string     `json:"width,omitempty"` // default、fill 或像素值
+	Icon      *Icon      `json:"icon,omitempty"`
+	Disabled  bool       `json:"disabled,omitempty"`
+	Url       string     `json:"url,omitempty"`   // 跳转链接（卡片1.0）
+	Value     any        `json:"value,omitempty"` // 回传数据（卡片1.0）
+	Confirm   *Confirm   `json:"confirm,omitempty"`
+	Behaviors []Behavior `json:"behaviors,omitempty"` // 交互行为（卡片2.0）
+	Margin    string     `json:"margin,omitempty"`
+
+	Name           string `json:"name,omitempty"`             // 表单中的按钮名称，在卡片内唯一
+	ActionType     string `json:"action_type,omitempty"`      // 卡片1.0：form_submit、form_reset
+	FormActionType string `json:"form_action_type,omitempty"` // 卡片2.0：submit、reset
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (ButtonElement) ElementTag() string { return "button" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e ButtonElement) MarshalJSON() ([]byte, error) {
+	type element ButtonElement
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// TableElement 表格组件
+type TableElement struct {
+	ElementID         string            `json:"element_id,omitempty"`
+	PageSize          int               `json:"page_size,omitempty"`  // 每页行数，1-10
+	RowHeight         string            `json:"row_height,omitempty"` // low、medium、high、auto 或像素值
+	FreezeFirstColumn bool              `json:"freeze_first_column,omitempty"`
+	HeaderStyle       *TableHeaderStyle `json:"header_style,omitempty"`
+	Columns           []TableColumn     `json:"columns"`
+	Rows              []map[string]any  `json:"rows"`
+	Margin            string            `json:"margin,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// TableHeaderStyle 表头样式
+type TableHeaderStyle struct {
+	TextAlign       string `json:"text_align,omitempty"`
+	TextSize        string `json:"text_size,omitempty"`
+	BackgroundStyle string `json:"background_style,omitempty"` // grey、none
+	TextColor       string `json:"text_color,omitempty"`
+	Bold            bool   `json:"bold,omitempty"`
+	Lines           int    `json:"lines,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// TableColumn 表格列定义，Name 对应 Rows 中的键
+type TableColumn struct {
+	Name            string             `json:"name"`
+	DisplayName     string             `json:"display_name,omitempty"`
+	DataType        string             `json:"data_type"` // text、lark_md、number、options、persons、date、markdown
+	Width           string             `json:"width,omitempty"`
+	HorizontalAlign string             `json:"horizontal_align,omitempty"`
+	Format          *TableNumberFormat `json:"format,omitempty"`      // number：数字格式
+	DateFormat      string             `json:"date_format,omitempty"` // date：日期格式，如 YYYY-MM-DD HH:mm
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (TableElement) ElementTag() string { return "table" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e TableElement) MarshalJSON() ([]byte, error) {
+	type element TableElement
+	if e.Columns == nil {
+		e.Columns = []TableColumn{}
+	}
+	if e.Rows == nil {
+		e.Rows = []map[string]any{}
+	}
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// ChartElement 图表组件，图表定义使用 VChart 规范
+type ChartElement struct {
+	ElementID   string    `json:"element_id,omitempty"`
+	ChartSpec   ChartSpec `json:"chart_spec"`
+	AspectRatio string    `json:"aspect_ratio,omitempty"` // 1:1、2:1、4:3、16:9
+	ColorTheme  string    `json:"color_theme,omitempty"`  // brand、rainbow、complementary、converse、primary
+	Preview     *bool     `json:"preview,omitempty"`      // 是否支持独立窗口查看，默认 true
+	Height      string    `json:"height,omitempty"`       // auto 或像素值（卡片2.0）
+	Margin      string    `json:"margin,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (ChartElement) ElementTag() string { return "chart" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e ChartElement) MarshalJSON() ([]byte, error) {
+	type element ChartElement
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// CollapsiblePanelElement 折叠面板，点击标题展开或收起内部的组件
+type CollapsiblePanelElement struct {
+	ElementID       string                 `json:"element_id,omitempty"`
+	Expanded        bool                   `json:"expanded"`                   // 是否默认展开
+	BackgroundColor string                 `json:"background_color,omitempty"` // 面板背景色
+	Header          CollapsiblePanelHeader `json:"header"`
+	Border          *PanelBorder           `json:"border,omitempty"`
+	VerticalSpacing string                 `json:"vertical_spacing,omitempty"` // 内部组件的垂直间距
+	Padding         string                 `json:"padding,omitempty"`
+	Margin          string                 `json:"margin,omitempty"`
+	Elements        []CardElement          `json:"elements"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// CollapsiblePanelHeader 折叠面板的标题栏
+type CollapsiblePanelHeader struct {
+	Title             *Text  `json:"title"` // 标题，tag 为 markdown 或 plain_text
+	BackgroundColor   string `json:"background_color,omitempty"`
+	VerticalAlign     string `json:"vertical_align,omitempty"` // top、center、bottom
+	Padding           string `json:"padding,omitempty"`
+	Icon              *Icon  `json:"icon,omitempty"`
+	IconPosition      string `json:"icon_position,omitempty"`       // left、right、follow_text
+	IconExpandedAngle int    `json:"icon_expanded_angle,omitempty"` // 展开时图标的旋转角度，如 -180
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// PanelBorder 折叠面板的边框
+type PanelBorder struct {
+	Color        string `json:"color,omitempty"`
+	CornerRadius string `json:"corner_radius,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (CollapsiblePanelElement) ElementTag() string { return "collapsible_panel" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e CollapsiblePanelElement) MarshalJSON() ([]byte, error) {
+	type element CollapsiblePanelElement
+	if e.Elements == nil {
+		e.Elements = []CardElement{}
+	}
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// FormElement 表单容器，内部的输入类组件随提交按钮一起回传
+type FormElement struct {
+	ElementID string        `json:"element_id,omitempty"`
+	Name      string        `json:"name"` // 表单名称，在卡片内唯一
+	Elements  []CardElement `json:"elements"`
+	Margin    string        `json:"margin,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (FormElement) ElementTag() string { return "form" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e FormElement) MarshalJSON() ([]byte, error) {
+	type element FormElement
+	if e.Elements == nil {
+		e.Elements = []CardElement{}
+	}
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// marshalElement 序列化组件并在最前面插入 tag，末尾追加未知字段
+func marshalElement(tag string, v any, unknown map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = appendUnknown(data, unknown); err != nil {
+		return nil, err
+	}
+	tagJSON, err := json.Marshal(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(data)+len(tagJSON)+8)
+	out = append(out, `{"tag":`...)
+	out = append(out, tagJSON...)
+	if len(data) > 2 {
+		out = append(out, ',')
+	}
+	return append(out, data[1:]...), nil
+}
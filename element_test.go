@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// 测试各组件序列化时输出 tag
+func TestCardElementMarshal(t *testing.T) {
+	cases := []struct {
+		elem     CardElement
+		expected string
+	}{
+		{MarkdownElement{Content: "内容"}, `{"tag":"markdown","content":"内容"}`},
+		{HrElement{}, `{"tag":"hr"}`},
+		{ImageElement{ImgKey: "img_xxx", CustomWidth: 300}, `{"tag":"img","img_key":"img_xxx","custom_width":300}`},
+		{NoteElement{Elements: []CardElement{Text{Tag: "plain_text", Content: "备注"}}}, `{"tag":"note","elements":[{"content":"备注","tag":"plain_text"}]}`},
+		{ActionElement{}, `{"tag":"action","actions":[]}`},
+		{ButtonElement{Text: &Text{Tag: "plain_text", Content: "确认"}, Type: "primary"}, `{"tag":"button","text":{"content":"确认","tag":"plain_text"},"type":"primary"}`},
+		{ColumnSetElement{FlexMode: "none"}, `{"tag":"column_set","flex_mode":"none","columns":[]}`},
+		{FormElement{Name: "form_1"}, `{"tag":"form","name":"form_1","elements":[]}`},
+		{TableElement{PageSize: 5}, `{"tag":"table","page_size":5,"columns":[],"rows":[]}`},
+		{
+			DivElement{Text: &Text{Tag: "lark_md", Content: "**内容**"}, Extra: ImageElement{ImgKey: "img_xxx"}},
+			`{"tag":"div","text":{"content":"**内容**","tag":"lark_md"},"extra":{"tag":"img","img_key":"img_xxx"}}`,
+		},
+	}
+	for _, c := range cases {
+		data, err := json.Marshal(c.elem)
+		if err != nil {
+			t.Fatalf("%s 序列化失败: %v", c.elem.ElementTag(), err)
+		}
+		if string(data) != c.expected {
+			t.Errorf("%s 序列化结果不正确:\n期望 %s\n实际 %s", c.elem.ElementTag(), c.expected, data)
+		}
+	}
+}
+
+// 测试新组件与旧的 Element 混合使用
+func TestCardElementMixed(t *testing.T) {
+	card := Card{
+		Header: Header{Title: Text{Tag: "plain_text", Content: "标题"}},
+		Elements: []CardElement{
+			CreateMarkdownElement("旧组件"),
+			HrElement{},
+			&MarkdownElement{Content: "指针组件"},
+			ColumnSetElement{Columns: []Column{CreateColumn("top", "列内容")}},
+		},
+	}
+	data, err := json.Marshal(card)
+	if err != nil {
+		t.Fatalf("序列化失败: %v", err)
+	}
+
+	var got struct {
+		Elements []map[string]any `json:"elements"`
+	}
+	_ = json.Unmarshal(data, &got)
+	expected := []string{"markdown", "hr", "markdown", "column_set"}
+	if len(got.Elements) != len(expected) {
+		t.Fatalf("组件数量不正确: %s", data)
+	}
+	for i, tag := range expected {
+		if got.Elements[i]["tag"] != tag {
+			t.Errorf("第 %d 个组件 tag 不正确: 期望 %s, 实际 %v", i, tag, got.Elements[i]["tag"])
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package bot
+
+import "encoding/json"
+
+/**
+ * @Description: 表单组件
+ * 文档 https://open.feishu.cn/document/feishu-cards/card-json-v2-components/containers/form-container
+ * 表单容器（FormElement）中的输入框、下拉选择、日期选择等组件不会单独回传，
+ * 用户点击提交按钮后，所有组件的值按 name 一起回传，因此容器和组件的 name 在卡片内必须唯一
+ * 提交按钮：卡片1.0 使用 action_type: form_submit，卡片2.0 使用 form_action_type: submit
+ */
+
+// 卡片1.0 按钮的 action_type
+const (
+	ButtonActionFormSubmit = "form_submit"
+	ButtonActionFormReset  = "form_reset"
+)
+
+// 卡片2.0 按钮的 form_action_type
+const (
+	FormActionSubmit = "submit"
+	FormActionReset  = "reset"
+)
+
+// InputElement 输入框
+type InputElement struct {
+	ElementID     string `json:"element_id,omitempty"`
+	Name          string `json:"name,omitempty"` // 表单中的组件名称，在卡片内唯一
+	Required      bool   `json:"required,omitempty"`
+	Disabled      bool   `json:"disabled,omitempty"`
+	Placeholder   *Text  `json:"placeholder,omitempty"`
+	DefaultValue  string `json:"default_value,omitempty"`
+	MaxLength     int    `json:"max_length,omitempty"` // 最大字符数，1-1000
+	InputType     string `json:"input_type,omitempty"` // text、multiline_text、password
+	Rows          int    `json:"rows,omitempty"`       // 多行文本的默认行数
+	Label         *Text  `json:"label,omitempty"`
+	LabelPosition string `json:"label_position,omitempty"` // top、left
+	Width         string `json:"width,omitempty"`          // default、fill 或像素值
+	Value         any    `json:"value,omitempty"`          // 回传数据
+	Margin        string `json:"margin,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (InputElement) ElementTag() string { return "input" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e InputElement) MarshalJSON() ([]byte, error) {
+	type element InputElement
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// SelectStaticElement 单选下拉
+type SelectStaticElement struct {
+	ElementID     string   `json:"element_id,omitempty"`
+	Name          string   `json:"name,omitempty"`
+	Required      bool     `json:"required,omitempty"`
+	Disabled      bool     `json:"disabled,omitempty"`
+	Type          string   `json:"type,omitempty"` // default、text
+	Placeholder   *Text    `json:"placeholder,omitempty"`
+	InitialOption string   `json:"initial_option,omitempty"` // 默认选中的选项值
+	Options       []Option `json:"options"`
+	Width         string   `json:"width,omitempty"`
+	Value         any      `json:"value,omitempty"`
+	Confirm       *Confirm `json:"confirm,omitempty"`
+	Margin        string   `json:"margin,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (SelectStaticElement) ElementTag() string { return "select_static" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e SelectStaticElement) MarshalJSON() ([]byte, error) {
+	type element SelectStaticElement
+	if e.Options == nil {
+		e.Options = []Option{}
+	}
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// MultiSelectStaticElement 多选下拉
+type MultiSelectStaticElement struct {
+	ElementID      string   `json:"element_id,omitempty"`
+	Name           string   `json:"name,omitempty"`
+	Required       bool     `json:"required,omitempty"`
+	Disabled       bool     `json:"disabled,omitempty"`
+	Type           string   `json:"type,omitempty"` // default、text
+	Placeholder    *Text    `json:"placeholder,omitempty"`
+	SelectedValues []string `json:"selected_values,omitempty"` // 默认选中的选项值
+	Options        []Option `json:"options"`
+	Width          string   `json:"width,omitempty"`
+	Value          any      `json:"value,omitempty"`
+	Confirm        *Confirm `json:"confirm,omitempty"`
+	Margin         string   `json:"margin,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (MultiSelectStaticElement) ElementTag() string { return "multi_select_static" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e MultiSelectStaticElement) MarshalJSON() ([]byte, error) {
+	type element MultiSelectStaticElement
+	if e.Options == nil {
+		e.Options = []Option{}
+	}
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// DatePickerElement 日期选择器
+type DatePickerElement struct {
+	ElementID   string   `json:"element_id,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+	Disabled    bool     `json:"disabled,omitempty"`
+	Placeholder *Text    `json:"placeholder,omitempty"`
+	InitialDate string   `json:"initial_date,omitempty"` // 默认日期，格式 YYYY-MM-DD
+	Width       string   `json:"width,omitempty"`
+	Value       any      `json:"value,omitempty"`
+	Confirm     *Confirm `json:"confirm,omitempty"`
+	Margin      string   `json:"margin,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (DatePickerElement) ElementTag() string { return "date_picker" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e DatePickerElement) MarshalJSON() ([]byte, error) {
+	type element DatePickerElement
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// TimePickerElement 时间选择器
+type TimePickerElement struct {
+	ElementID   string   `json:"element_id,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+	Disabled    bool     `json:"disabled,omitempty"`
+	Placeholder *Text    `json:"placeholder,omitempty"`
+	InitialTime string   `json:"initial_time,omitempty"` // 默认时间，格式 HH:mm
+	Width       string   `json:"width,omitempty"`
+	Value       any      `json:"value,omitempty"`
+	Confirm     *Confirm `json:"confirm,omitempty"`
+	Margin      string   `json:"margin,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (TimePickerElement) ElementTag() string { return "picker_time" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e TimePickerElement) MarshalJSON() ([]byte, error) {
+	type element TimePickerElement
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// CheckerElement 勾选器
+type CheckerElement struct {
+	ElementID        string     `json:"element_id,omitempty"`
+	Name             string     `json:"name,omitempty"`
+	Checked          bool       `json:"checked,omitempty"` // 默认是否勾选
+	Text             *Text      `json:"text,omitempty"`
+	OverallCheckable bool       `json:"overall_checkable,omitempty"` // 点击整个组件区域即可勾选
+	Disabled         bool       `json:"disabled,omitempty"`
+	Behaviors        []Behavior `json:"behaviors,omitempty"`
+	Margin           string     `json:"margin,omitempty"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// ElementTag 实现 CardElement
+func (CheckerElement) ElementTag() string { return "checker" }
+
+// MarshalJSON 输出带 tag 的组件
+func (e CheckerElement) MarshalJSON() ([]byte, error) {
+	type element CheckerElement
+	return marshalElement(e.ElementTag(), element(e), e.Unknown)
+}
+
+// CreateFormSubmitButton 创建表单的提交按钮，按卡片结构版本设置提交方式
+func CreateFormSubmitButton(text, name string, schema CardSchema) ButtonElement {
+	button := ButtonElement{
+		Name: name,
+		Text: &Text{Tag: "plain_text", Content: text},
+		Type: "primary",
+	}
+	if schema == CardSchemaV2 {
+		button.FormActionType = FormActionSubmit
+	} else {
+		button.ActionType = ButtonActionFormSubmit
+	}
+	return button
+}
+
+// isFormButton 是否为表单的提交或重置按钮
+func isFormButton(actionType, formActionType string) bool {
+	return actionType == ButtonActionFormSubmit || actionType == ButtonActionFormReset ||
+		formActionType == FormActionSubmit || formActionType == FormActionReset
+}
+
+// isFormSubmit 是否为表单的提交按钮
+func isFormSubmit(actionType, formActionType string) bool {
+	return actionType == ButtonActionFormSubmit || formActionType == FormActionSubmit
+}
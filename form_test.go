@@ -0,0 +1,137 @@
+package bot
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// approvalHeader 测试用的审批卡片标题
+var approvalHeader = Header{Title: Text{Tag: "plain_text", Content: "审批"}}
+
+// approvalForm 测试用的审批表单
+func approvalForm(schema CardSchema) FormElement {
+	return FormElement{
+		Name: "approval",
+		Elements: []CardElement{
+			InputElement{
+				Name:        "reason",
+				Required:    true,
+				Placeholder: &Text{Tag: "plain_text", Content: "请输入原因"},
+				MaxLength:   200,
+			},
+			SelectStaticElement{
+				Name: "env",
+				Options: []Option{
+					{Text: Text{Tag: "plain_text", Content: "生产"}, Value: "prod"},
+					{Text: Text{Tag: "plain_text", Content: "测试"}, Value: "test"},
+				},
+			},
+			MultiSelectStaticElement{
+				Name:    "services",
+				Options: []Option{{Text: Text{Tag: "plain_text", Content: "api"}, Value: "api"}},
+			},
+			DatePickerElement{Name: "date", InitialDate: "2024-01-02"},
+			TimePickerElement{Name: "time", InitialTime: "10:00"},
+			CheckerElement{Name: "confirm", Text: &Text{Tag: "plain_text", Content: "我已确认"}},
+			CreateFormSubmitButton("提交", "submit", schema),
+		},
+	}
+}
+
+// 测试表单组件的序列化和解码
+func TestFormElementsJSON(t *testing.T) {
+	form := approvalForm(CardSchemaV2)
+	data, err := json.Marshal(form)
+	if err != nil {
+		t.Fatalf("序列化失败: %v", err)
+	}
+	for _, s := range []string{
+		`"tag":"input"`, `"max_length":200`, `"required":true`,
+		`"tag":"select_static"`, `"tag":"multi_select_static"`,
+		`"tag":"date_picker"`, `"tag":"picker_time"`, `"tag":"checker"`,
+		`"form_action_type":"submit"`,
+	} {
+		if !strings.Contains(string(data), s) {
+			t.Errorf("序列化结果缺少 %s: %s", s, data)
+		}
+	}
+
+	elem, err := UnmarshalCardElement(data)
+	if err != nil {
+		t.Fatalf("解码失败: %v", err)
+	}
+	if !reflect.DeepEqual(elem, form) {
+		t.Errorf("解码结果不一致:\n期望 %+v\n实际 %+v", form, elem)
+	}
+
+	v1, _ := json.Marshal(CreateFormSubmitButton("提交", "submit", CardSchemaV1))
+	if !strings.Contains(string(v1), `"action_type":"form_submit"`) || strings.Contains(string(v1), "form_action_type") {
+		t.Errorf("卡片1.0 提交按钮不正确: %s", v1)
+	}
+}
+
+// 测试表单校验
+func TestValidateForm(t *testing.T) {
+	for _, schema := range []CardSchema{CardSchemaV1, CardSchemaV2} {
+		card := &Card{Schema: string(schema), Header: approvalHeader, Elements: []CardElement{approvalForm(schema)}}
+		if err := Validate(NewCardMsg(card)); err != nil {
+			t.Errorf("%s 校验失败: %v", schema, err)
+		}
+	}
+
+	dup := approvalForm(CardSchemaV1)
+	dup.Elements = append(dup.Elements, InputElement{Name: "reason"}, DatePickerElement{})
+	other := approvalForm(CardSchemaV1)
+	other.Elements = []CardElement{InputElement{Name: "note"}, FormElement{Name: "nested"}}
+	card := &Card{Elements: []CardElement{
+		dup,
+		other,
+		CreateFormSubmitButton("提交", "", CardSchemaV1),
+		InputElement{MaxLength: 2000},
+		SelectStaticElement{},
+	}}
+	paths := validationPaths(t, Validate(NewCardMsg(card)))
+	for _, p := range []string{
+		"card.elements[0].elements[7].name", // 重复的组件名称
+		"card.elements[0].elements[8].name", // 表单中缺少名称
+		"card.elements[1].name",             // 重复的表单名称
+		"card.elements[1]",                  // 缺少提交按钮
+		"card.elements[1].elements[1]",      // 嵌套表单
+		"card.elements[2]",                  // 表单外的提交按钮
+		"card.elements[3].max_length",       // 超出最大长度
+		"card.elements[4].options",          // 缺少选项
+	} {
+		if _, ok := paths[p]; !ok {
+			t.Errorf("缺少校验错误 %s: %v", p, paths)
+		}
+	}
+	if _, ok := paths["card.elements[3].name"]; ok {
+		t.Error("表单外的组件不需要名称")
+	}
+}
+
+// 测试旧的 Action 支持表单提交
+func TestActionFormSubmit(t *testing.T) {
+	form := FormElement{
+		Name: "form",
+		Elements: []CardElement{
+			InputElement{Name: "reason"},
+			Element{Tag: "action", Actions: []Action{{
+				Tag:        "button",
+				Text:       &Text{Tag: "plain_text", Content: "提交"},
+				Name:       "submit",
+				ActionType: ButtonActionFormSubmit,
+			}}},
+		},
+	}
+	if err := Validate(NewCardMsg(&Card{Header: approvalHeader, Elements: []CardElement{form}})); err != nil {
+		t.Errorf("校验失败: %v", err)
+	}
+
+	data, _ := json.Marshal(form.Elements[1])
+	if !strings.Contains(string(data), `"name":"submit","action_type":"form_submit"`) {
+		t.Errorf("序列化结果不正确: %s", data)
+	}
+}
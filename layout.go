@@ -0,0 +1,122 @@
+package bot
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+/**
+ * @Description: 键值对布局
+ * 默认所有键值对输出为一个 markdown 组件，每项一行；状态、耗时这类较短的值可以并排展示：
+ * fields：使用 div 组件的 fields（is_short），两列并排（卡片2.0不支持，使用 grid2 代替）
+ * grid2、grid3：使用多列布局，每行两列或三列
+ * 多行、超过 ShortValueRunes 的值以及没有键的纯内容会自动使用整行展示
+ */
+
+// Layout 键值对的布局方式
+type Layout string
+
+const (
+	LayoutMarkdown Layout = ""       // 每项一行（默认）
+	LayoutFields   Layout = "fields" // div 组件的 fields，两列并排
+	LayoutGrid2    Layout = "grid2"  // 多列布局，每行两列
+	LayoutGrid3    Layout = "grid3"  // 多列布局，每行三列
+)
+
+// DefaultShortValueRunes 默认并排展示的值的最大字符数
+const DefaultShortValueRunes = 20
+
+// isShortEntry 是否可以并排展示
+func (f *FeishuMsg) isShortEntry(item Text) bool {
+	limit := f.ShortValueRunes
+	if limit <= 0 {
+		limit = DefaultShortValueRunes
+	}
+	return item.Tag != "" && !strings.Contains(item.Content, "\n") && utf8.RuneCountInString(item.Content) <= limit
+}
+
+// buildContentElements 按 Layout 构建内容组件
+func (f *FeishuMsg) buildContentElements() []CardElement {
+	if f.Layout == LayoutMarkdown {
+		if content := f.buildMarkdownContent(); content != "" {
+			return []CardElement{CreateMarkdownElement(content)}
+		}
+		return nil
+	}
+
+	layout := f.shortLayout()
+
+	var elements []CardElement
+	var short, long []Text
+	flush := func() {
+		if len(long) > 0 {
+			elements = append(elements, CreateMarkdownElement(formatMarkdownEntries(long)))
+			long = nil
+		}
+		if len(short) > 0 {
+			elements = append(elements, createShortElements(layout, short)...)
+			short = nil
+		}
+	}
+	for _, item := range f.contentEntries() {
+		if f.isShortEntry(item) {
+			if len(long) > 0 {
+				flush()
+			}
+			short = append(short, item)
+		} else {
+			if len(short) > 0 {
+				flush()
+			}
+			long = append(long, item)
+		}
+	}
+	flush()
+	return elements
+}
+
+// shortLayout 返回并排展示使用的布局，卡片2.0不支持 fields，使用 grid2 代替
+func (f *FeishuMsg) shortLayout() Layout {
+	if f.Layout == LayoutFields && f.Schema == CardSchemaV2 {
+		return LayoutGrid2
+	}
+	return f.Layout
+}
+
+// shortEntryContent 并排展示时键和值分两行
+func shortEntryContent(item Text) string {
+	return "**" + item.Tag + "**\n" + item.Content
+}
+
+// createShortElements 将较短的键值对按布局并排展示
+func createShortElements(layout Layout, items []Text) []CardElement {
+	if layout == LayoutFields {
+		fields := make([]Field, 0, len(items))
+		for _, item := range items {
+			fields = append(fields, Field{
+				IsShort: true,
+				Text:    &Text{Tag: "lark_md", Content: shortEntryContent(item)},
+			})
+		}
+		return []CardElement{DivElement{Fields: fields}}
+	}
+
+	cols := 2
+	if layout == LayoutGrid3 {
+		cols = 3
+	}
+	elements := make([]CardElement, 0, (len(items)+cols-1)/cols)
+	for i := 0; i < len(items); i += cols {
+		columns := make([]Column, 0, cols)
+		for j := i; j < i+cols; j++ {
+			if j < len(items) {
+				columns = append(columns, CreateColumn("top", shortEntryContent(items[j])))
+			} else {
+				// 补齐空列，保证各行对齐
+				columns = append(columns, Column{Tag: "column", Width: "weighted", Weight: 1})
+			}
+		}
+		elements = append(elements, CreateColumnSetElement(columns, "none"))
+	}
+	return elements
+}
@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// layoutMsg 测试用的键值对内容
+func layoutMsg(layout Layout) *FeishuMsg {
+	return &FeishuMsg{
+		Title: "部署完成",
+		MarkdownArray: [][2]string{
+			{"状态", "成功"},
+			{"耗时", "12s"},
+			{"环境", "prod"},
+			{"变更", strings.Repeat("很长的变更说明", 5)},
+			{"版本", "v1.2.0"},
+		},
+		Note:   "备注",
+		Layout: layout,
+	}
+}
+
+// 测试 fields 布局
+func TestLayoutFields(t *testing.T) {
+	elements := FormatMsg(layoutMsg(LayoutFields)).Card.Elements
+
+	// div(状态、耗时、环境) -> markdown(变更) -> div(版本) -> note
+	expected := []string{"div", "markdown", "div", "note"}
+	if len(elements) != len(expected) {
+		t.Fatalf("组件数量不正确: %d", len(elements))
+	}
+	for i, tag := range expected {
+		if elements[i].ElementTag() != tag {
+			t.Errorf("第 %d 个组件不正确: 期望 %s, 实际 %s", i, tag, elements[i].ElementTag())
+		}
+	}
+
+	div := elements[0].(DivElement)
+	if len(div.Fields) != 3 || !div.Fields[0].IsShort || div.Fields[0].Text.Content != "**状态**\n成功" {
+		t.Errorf("fields 不正确: %+v", div.Fields)
+	}
+	if md := elements[1].(Element); !strings.HasPrefix(md.Content, "**变更**：") {
+		t.Errorf("较长的值应该整行展示: %s", md.Content)
+	}
+
+	data, _ := json.Marshal(elements[0])
+	if !strings.Contains(string(data), `"is_short":true`) {
+		t.Errorf("序列化结果不正确: %s", data)
+	}
+}
+
+// 测试多列布局
+func TestLayoutGrid(t *testing.T) {
+	cases := []struct {
+		layout  Layout
+		columns []int // 每个多列布局的列数
+	}{
+		{LayoutGrid2, []int{2, 2, 2}},
+		{LayoutGrid3, []int{3, 3}},
+	}
+	for _, c := range cases {
+		elements := FormatMsg(layoutMsg(c.layout)).Card.Elements
+		var columns []int
+		for _, e := range elements {
+			if elem, ok := e.(Element); ok && elem.Tag == "column_set" {
+				columns = append(columns, len(elem.Columns))
+			}
+		}
+		if len(columns) != len(c.columns) {
+			t.Errorf("%s 多列布局数量不正确: %v", c.layout, columns)
+			continue
+		}
+		for i := range columns {
+			if columns[i] != c.columns[i] {
+				t.Errorf("%s 列数不正确: 期望 %v, 实际 %v", c.layout, c.columns, columns)
+			}
+		}
+	}
+
+	// grid2 第二行补齐空列
+	elements := FormatMsg(layoutMsg(LayoutGrid2)).Card.Elements
+	second := elements[1].(Element)
+	if len(second.Columns[1].Elements) != 0 {
+		t.Errorf("应该补齐空列: %+v", second.Columns[1])
+	}
+}
+
+// 测试卡片2.0中 fields 布局使用多列布局代替
+func TestLayoutFieldsV2(t *testing.T) {
+	f := layoutMsg(LayoutFields)
+	f.Schema = CardSchemaV2
+	for _, e := range FormatMsg(f).Card.Body.Elements {
+		if e.ElementTag() == "div" {
+			t.Error("卡片2.0不应该使用 div fields")
+		}
+	}
+}
+
+// 测试默认布局输出不变
+func TestLayoutMarkdown(t *testing.T) {
+	f := layoutMsg(LayoutMarkdown)
+	elements := FormatMsg(f).Card.Elements
+	if len(elements) != 2 || elements[0].(Element).Content != f.buildMarkdownContent() {
+		t.Errorf("默认布局应该只有一个 markdown 组件: %+v", elements)
+	}
+}
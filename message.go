@@ -0,0 +1,98 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+/**
+ * @Description: 自定义机器人消息类型
+ * 文档 https://open.feishu.cn/document/client-docs/bot-v3/add-custom-bot#5a997364
+ * text：文本          {"msg_type":"text","content":{"text":"..."}}
+ * post：富文本        {"msg_type":"post","content":{"post":{"zh_cn":{...}}}}
+ * image：图片         {"msg_type":"image","content":{"image_key":"img_xxx"}}
+ * share_chat：分享群名片 {"msg_type":"share_chat","content":{"share_chat_id":"oc_xxx"}}
+ * interactive：消息卡片 {"msg_type":"interactive","card":{...}}
+ */
+
+// 消息类型
+const (
+	MsgTypeText        = "text"        // 文本消息
+	MsgTypePost        = "post"        // 富文本消息
+	MsgTypeImage       = "image"       // 图片消息
+	MsgTypeShareChat   = "share_chat"  // 分享群名片
+	MsgTypeInteractive = "interactive" // 消息卡片
+)
+
+// Content 非卡片消息的内容
+type Content struct {
+	Text        string       `json:"text,omitempty"`          // 文本消息内容
+	Post        *PostContent `json:"post,omitempty"`          // 富文本消息内容
+	ImageKey    string       `json:"image_key,omitempty"`     // 图片消息的图片key
+	ShareChatID string       `json:"share_chat_id,omitempty"` // 分享的群ID
+}
+
+// NewImageMsg 构建一条图片消息，imageKey 需要先通过上传图片接口获取
+func NewImageMsg(imageKey string) *Msg {
+	return &Msg{
+		MsgType: MsgTypeImage,
+		Content: &Content{ImageKey: imageKey},
+	}
+}
+
+// NewShareChatMsg 构建一条分享群名片消息
+func NewShareChatMsg(chatID string) *Msg {
+	return &Msg{
+		MsgType: MsgTypeShareChat,
+		Content: &Content{ShareChatID: chatID},
+	}
+}
+
+// MarshalJSON 按消息类型输出：消息卡片输出 card 字段，其余类型输出 content 字段
+func (m Msg) MarshalJSON() ([]byte, error) {
+	type msg Msg
+	switch m.MsgType {
+	case MsgTypeInteractive, "":
+		m.Content = nil
+		return json.Marshal(msg(m))
+	default:
+		return json.Marshal(struct {
+			MsgType   string   `json:"msg_type"`
+			Content   *Content `json:"content"`
+			Timestamp string   `json:"timestamp,omitempty"`
+			Sign      string   `json:"sign,omitempty"`
+		}{m.MsgType, m.Content, m.Timestamp, m.Sign})
+	}
+}
+
+// ValidateContent 校验消息类型及其必填字段
+func (m *Msg) ValidateContent() error {
+	if m.MsgType == MsgTypeInteractive {
+		return nil
+	}
+
+	c := m.Content
+	switch m.MsgType {
+	case MsgTypeText:
+		if c == nil || c.Text == "" {
+			return fmt.Errorf("invalid message: %s message requires content.text", m.MsgType)
+		}
+	case MsgTypePost:
+		if c == nil || c.Post == nil || (c.Post.ZhCn == nil && c.Post.EnUs == nil && c.Post.JaJp == nil) {
+			return fmt.Errorf("invalid message: %s message requires content.post", m.MsgType)
+		}
+	case MsgTypeImage:
+		if c == nil || c.ImageKey == "" {
+			return fmt.Errorf("invalid message: %s message requires content.image_key", m.MsgType)
+		}
+	case MsgTypeShareChat:
+		if c == nil || c.ShareChatID == "" {
+			return fmt.Errorf("invalid message: %s message requires content.share_chat_id", m.MsgType)
+		}
+	case "":
+		return fmt.Errorf("invalid message: msg_type is empty")
+	default:
+		return fmt.Errorf("invalid message: unsupported msg_type %q", m.MsgType)
+	}
+	return nil
+}
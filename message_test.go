@@ -0,0 +1,93 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// 测试按消息类型序列化
+func TestMsgMarshalByType(t *testing.T) {
+	cases := []struct {
+		msg      *Msg
+		expected string
+	}{
+		{NewImageMsg("img_v3_xxx"), `{"msg_type":"image","content":{"image_key":"img_v3_xxx"}}`},
+		{NewShareChatMsg("oc_xxx"), `{"msg_type":"share_chat","content":{"share_chat_id":"oc_xxx"}}`},
+		{NewTextMsg("你好"), `{"msg_type":"text","content":{"text":"你好"}}`},
+	}
+	for _, c := range cases {
+		data, err := json.Marshal(c.msg)
+		if err != nil {
+			t.Fatalf("序列化失败: %v", err)
+		}
+		if string(data) != c.expected {
+			t.Errorf("序列化结果不正确:\n期望 %s\n实际 %s", c.expected, data)
+		}
+	}
+
+	// 消息卡片只输出 card 字段
+	card := FormatMsg(&FeishuMsg{Title: "卡片", Note: "备注"})
+	card.Content = &Content{Text: "不应该输出"}
+	data, _ := json.Marshal(card)
+	var got map[string]any
+	_ = json.Unmarshal(data, &got)
+	if got["card"] == nil || got["content"] != nil {
+		t.Errorf("消息卡片序列化不正确: %s", data)
+	}
+}
+
+// 测试必填字段校验
+func TestMsgValidateContent(t *testing.T) {
+	valid := []*Msg{
+		NewTextMsg("你好"),
+		NewImageMsg("img_v3_xxx"),
+		NewShareChatMsg("oc_xxx"),
+		NewPost().Line(PostText("内容")).Build(),
+		FormatMsg(&FeishuMsg{Title: "卡片"}),
+	}
+	for _, msg := range valid {
+		if err := msg.ValidateContent(); err != nil {
+			t.Errorf("%s 消息不应该返回错误: %v", msg.MsgType, err)
+		}
+	}
+
+	invalid := []*Msg{
+		NewTextMsg(""),
+		NewImageMsg(""),
+		NewShareChatMsg(""),
+		{MsgType: MsgTypePost, Content: &Content{Post: &PostContent{}}},
+		{MsgType: MsgTypeImage},
+		{MsgType: "audio", Content: &Content{}},
+		{},
+	}
+	for _, msg := range invalid {
+		if err := msg.ValidateContent(); err == nil {
+			t.Errorf("%q 消息缺少必填字段，应该返回错误", msg.MsgType)
+		}
+	}
+}
+
+// 测试发送前校验，无效消息不会发出请求
+func TestClientSendInvalidMsg(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithHook(server.URL))
+	if _, err := client.SendMsg(context.Background(), NewImageMsg("")); err == nil {
+		t.Error("缺少 image_key 应该返回错误")
+	}
+	if _, err := client.SendMsg(context.Background(), NewShareChatMsg("oc_xxx")); err != nil {
+		t.Errorf("发送失败: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("应该只发出 1 次请求，实际 %d 次", calls)
+	}
+}
@@ -0,0 +1,347 @@
+package bot
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+/**
+ * @Description: 本地持久化发件箱
+ * 以 JSON Lines 格式追加写入日志文件，每行是一条记录：
+ * {"op":"put","seq":1,"msg":{...}}  写入一条待发送消息
+ * {"op":"fail","seq":1}             记录一次发送失败，用于限制重试次数
+ * {"op":"ack","seq":1}              确认消息已发送（或放弃发送）
+ * 打开时重放日志，未确认的消息会重新发送；确认的记录达到阈值或全部确认后压缩文件。
+ * 进程崩溃可能导致最后一行写入不完整，打开时会压缩文件去掉这一行，避免后续记录写在不完整的行后面。
+ */
+
+const (
+	outboxOpPut  = "put"
+	outboxOpFail = "fail"
+	outboxOpAck  = "ack"
+
+	// outboxMaxLine 单行记录的最大长度
+	outboxMaxLine = 4 << 20
+)
+
+// outboxRecord 日志中的一条记录
+type outboxRecord struct {
+	Op       string          `json:"op"`
+	Seq      uint64          `json:"seq"`
+	Msg      json.RawMessage `json:"msg,omitempty"`
+	Attempts int             `json:"attempts,omitempty"` // 压缩时保留的失败次数
+}
+
+// OutboxEntry 发件箱中未确认的消息
+type OutboxEntry struct {
+	Seq      uint64
+	Msg      *Msg
+	Attempts int // 发送失败的次数
+}
+
+// Outbox 基于本地文件的发件箱，可在多个 goroutine 中并发使用
+type Outbox struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	nextSeq uint64
+	order   []uint64        // 未确认消息的顺序
+	pending map[uint64]*Msg // 未确认的消息
+	fails   map[uint64]int  // 未确认消息的失败次数
+	acked   int             // 上次压缩后确认的记录数
+
+	// CompactThreshold 确认记录数达到该值时自动压缩，默认 100
+	CompactThreshold int
+}
+
+// OpenOutbox 打开（或创建）发件箱文件，并重放其中未确认的消息
+func OpenOutbox(path string) (*Outbox, error) {
+	o := &Outbox{
+		path:             path,
+		nextSeq:          1,
+		pending:          make(map[uint64]*Msg),
+		fails:            make(map[uint64]int),
+		CompactThreshold: 100,
+	}
+	torn, err := o.replay()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox: %w", err)
+	}
+	o.file = file
+
+	// 最后一行不完整时重写文件，否则新记录会追加在这一行后面
+	if torn {
+		if err := o.compact(); err != nil {
+			o.file.Close()
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// replay 读取日志，恢复未确认的消息，最后一行不完整时返回 torn 为 true
+func (o *Outbox) replay() (torn bool, err error) {
+	data, err := os.ReadFile(o.path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read outbox: %w", err)
+	}
+	// 每条记录都以换行结束，没有换行说明最后一条记录没有写完
+	torn = len(data) > 0 && data[len(data)-1] != '\n'
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), outboxMaxLine)
+	var lines [][]byte
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			lines = append(lines, append([]byte(nil), line...))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read outbox: %w", err)
+	}
+
+	for i, line := range lines {
+		var rec outboxRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			// 进程崩溃可能导致最后一行写入不完整，忽略后由 OpenOutbox 重写文件
+			if i == len(lines)-1 {
+				torn = true
+				break
+			}
+			return false, fmt.Errorf("failed to decode outbox record at line %d: %w", i+1, err)
+		}
+
+		switch rec.Op {
+		case outboxOpPut:
+			var msg Msg
+			if err := json.Unmarshal(rec.Msg, &msg); err != nil {
+				return false, fmt.Errorf("failed to decode outbox message %d: %w", rec.Seq, err)
+			}
+			if _, ok := o.pending[rec.Seq]; !ok {
+				o.order = append(o.order, rec.Seq)
+			}
+			o.pending[rec.Seq] = &msg
+			o.fails[rec.Seq] = rec.Attempts
+		case outboxOpFail:
+			if _, ok := o.pending[rec.Seq]; ok {
+				o.fails[rec.Seq]++
+			}
+		case outboxOpAck:
+			if _, ok := o.pending[rec.Seq]; ok {
+				delete(o.pending, rec.Seq)
+				delete(o.fails, rec.Seq)
+				o.acked++
+			}
+		}
+		if rec.Seq >= o.nextSeq {
+			o.nextSeq = rec.Seq + 1
+		}
+	}
+	o.order = o.pendingOrder()
+	return torn, nil
+}
+
+// pendingOrder 返回仍未确认的消息序号（保持写入顺序）
+func (o *Outbox) pendingOrder() []uint64 {
+	order := make([]uint64, 0, len(o.pending))
+	for _, seq := range o.order {
+		if _, ok := o.pending[seq]; ok {
+			order = append(order, seq)
+		}
+	}
+	return order
+}
+
+// write 追加写入一条记录
+func (o *Outbox) write(rec outboxRecord, sync bool) error {
+	if o.file == nil {
+		return fmt.Errorf("outbox is closed")
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox record: %w", err)
+	}
+	if _, err := o.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+	if sync {
+		if err := o.file.Sync(); err != nil {
+			return fmt.Errorf("failed to sync outbox: %w", err)
+		}
+	}
+	return nil
+}
+
+// Put 持久化一条待发送消息，返回消息序号
+func (o *Outbox) Put(msg *Msg) (uint64, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	seq := o.nextSeq
+	if err := o.write(outboxRecord{Op: outboxOpPut, Seq: seq, Msg: data}, true); err != nil {
+		return 0, err
+	}
+	o.nextSeq++
+	o.order = append(o.order, seq)
+	o.pending[seq] = msg
+	return seq, nil
+}
+
+// Ack 确认消息已发送，达到阈值时自动压缩文件
+func (o *Outbox) Ack(seq uint64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.pending[seq]; !ok {
+		return nil
+	}
+	if err := o.write(outboxRecord{Op: outboxOpAck, Seq: seq}, false); err != nil {
+		return err
+	}
+	delete(o.pending, seq)
+	delete(o.fails, seq)
+	o.acked++
+
+	if len(o.pending) == 0 || (o.CompactThreshold > 0 && o.acked >= o.CompactThreshold) {
+		return o.compact()
+	}
+	return nil
+}
+
+// Fail 记录一次发送失败，返回该消息累计的失败次数
+func (o *Outbox) Fail(seq uint64) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.pending[seq]; !ok {
+		return 0, nil
+	}
+	if err := o.write(outboxRecord{Op: outboxOpFail, Seq: seq}, false); err != nil {
+		return o.fails[seq], err
+	}
+	o.fails[seq]++
+	return o.fails[seq], nil
+}
+
+// Pending 返回所有未确认的消息（按写入顺序）
+func (o *Outbox) Pending() []OutboxEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.order = o.pendingOrder()
+	entries := make([]OutboxEntry, 0, len(o.order))
+	for _, seq := range o.order {
+		entries = append(entries, OutboxEntry{Seq: seq, Msg: o.pending[seq], Attempts: o.fails[seq]})
+	}
+	return entries
+}
+
+// Compact 压缩日志文件，只保留未确认的消息
+func (o *Outbox) Compact() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.compact()
+}
+
+// compact 将未确认的消息写入临时文件后替换原文件
+func (o *Outbox) compact() error {
+	if o.file == nil {
+		return fmt.Errorf("outbox is closed")
+	}
+
+	o.order = o.pendingOrder()
+	var buf bytes.Buffer
+	for _, seq := range o.order {
+		data, err := json.Marshal(o.pending[seq])
+		if err != nil {
+			return fmt.Errorf("failed to marshal message: %w", err)
+		}
+		line, err := json.Marshal(outboxRecord{Op: outboxOpPut, Seq: seq, Msg: data, Attempts: o.fails[seq]})
+		if err != nil {
+			return fmt.Errorf("failed to marshal outbox record: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	tmp := o.path + ".tmp"
+	if err := writeFileSync(tmp, buf.Bytes()); err != nil {
+		return err
+	}
+	if err := o.file.Close(); err != nil {
+		return fmt.Errorf("failed to close outbox: %w", err)
+	}
+	o.file = nil
+	if err := os.Rename(tmp, o.path); err != nil {
+		return fmt.Errorf("failed to replace outbox: %w", err)
+	}
+
+	file, err := os.OpenFile(o.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open outbox: %w", err)
+	}
+	o.file = file
+	o.acked = 0
+	return nil
+}
+
+// writeFileSync 写入文件并落盘
+func writeFileSync(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to sync %s: %w", path, err)
+	}
+	return file.Close()
+}
+
+// Flush 按顺序发送所有未确认的消息，发送成功后确认；遇到错误时停止并返回
+func (o *Outbox) Flush(ctx context.Context, c *Client) error {
+	for _, entry := range o.Pending() {
+		if _, err := c.SendMsg(ctx, entry.Msg); err != nil {
+			return fmt.Errorf("failed to flush outbox message %d: %w", entry.Seq, err)
+		}
+		if err := o.Ack(entry.Seq); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close 关闭发件箱文件
+func (o *Outbox) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.file == nil {
+		return nil
+	}
+	err := o.file.Close()
+	o.file = nil
+	return err
+}
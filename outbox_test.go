@@ -0,0 +1,343 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 测试重新打开后恢复未确认的消息
+func TestOutboxReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.log")
+
+	o, err := OpenOutbox(path)
+	if err != nil {
+		t.Fatalf("打开发件箱失败: %v", err)
+	}
+	o.CompactThreshold = 0
+	seq1, _ := o.Put(FormatMsg(&FeishuMsg{Title: "消息1"}))
+	seq2, _ := o.Put(FormatMsg(&FeishuMsg{Title: "消息2"}))
+	seq3, _ := o.Put(FormatMsg(&FeishuMsg{Title: "消息3"}))
+	if err := o.Ack(seq2); err != nil {
+		t.Fatalf("确认失败: %v", err)
+	}
+	_ = o.Close()
+
+	// 模拟崩溃导致最后一行写入不完整
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	_, _ = f.WriteString(`{"op":"put","seq":4,"msg":{"msg_ty`)
+	_ = f.Close()
+
+	o, err = OpenOutbox(path)
+	if err != nil {
+		t.Fatalf("重新打开发件箱失败: %v", err)
+	}
+	defer o.Close()
+
+	pending := o.Pending()
+	if len(pending) != 2 || pending[0].Seq != seq1 || pending[1].Seq != seq3 {
+		t.Fatalf("未确认的消息不正确: %+v", pending)
+	}
+	if pending[0].Msg.Card.Header.Title.Content != "消息1" {
+		t.Errorf("消息内容不正确: %s", pending[0].Msg.Card.Header.Title.Content)
+	}
+
+	seq4, _ := o.Put(FormatMsg(&FeishuMsg{Title: "消息4"}))
+	if seq4 <= seq3 {
+		t.Errorf("新消息序号应该递增，实际 %d", seq4)
+	}
+}
+
+// 测试崩溃留下不完整的最后一行后，继续写入的消息不会丢失
+func TestOutboxTornTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.log")
+	o, _ := OpenOutbox(path)
+	_, _ = o.Put(FormatMsg(&FeishuMsg{Title: "a"}))
+	_ = o.Close()
+
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	_, _ = f.WriteString(`{"op":"put","seq":2,"msg":{"msg_ty`)
+	_ = f.Close()
+
+	o, err := OpenOutbox(path)
+	if err != nil {
+		t.Fatalf("重新打开发件箱失败: %v", err)
+	}
+	_, _ = o.Put(FormatMsg(&FeishuMsg{Title: "b"}))
+	_, _ = o.Put(FormatMsg(&FeishuMsg{Title: "c"}))
+	_ = o.Close()
+
+	o, err = OpenOutbox(path)
+	if err != nil {
+		t.Fatalf("再次打开发件箱失败: %v", err)
+	}
+	defer o.Close()
+	var titles []string
+	for _, entry := range o.Pending() {
+		titles = append(titles, entry.Msg.Card.Header.Title.Content)
+	}
+	if strings.Join(titles, ",") != "a,b,c" {
+		t.Errorf("未确认的消息不正确: %v", titles)
+	}
+}
+
+// 测试失败次数在重新打开和压缩后保留
+func TestOutboxFailAttempts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.log")
+	o, _ := OpenOutbox(path)
+	o.CompactThreshold = 1
+	seq1, _ := o.Put(FormatMsg(&FeishuMsg{Title: "a"}))
+	seq2, _ := o.Put(FormatMsg(&FeishuMsg{Title: "b"}))
+	_, _ = o.Fail(seq2)
+	if n, _ := o.Fail(seq2); n != 2 {
+		t.Errorf("失败次数应该为 2，实际 %d", n)
+	}
+	_ = o.Close()
+
+	o, _ = OpenOutbox(path)
+	if pending := o.Pending(); len(pending) != 2 || pending[1].Attempts != 2 {
+		t.Fatalf("重新打开后失败次数不正确: %+v", pending)
+	}
+	_ = o.Ack(seq1) // 达到阈值，压缩文件
+	_ = o.Close()
+
+	o, _ = OpenOutbox(path)
+	defer o.Close()
+	if pending := o.Pending(); len(pending) != 1 || pending[0].Attempts != 2 {
+		t.Errorf("压缩后失败次数不正确: %+v", pending)
+	}
+}
+
+// 测试全部确认后压缩文件
+func TestOutboxCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.log")
+	o, err := OpenOutbox(path)
+	if err != nil {
+		t.Fatalf("打开发件箱失败: %v", err)
+	}
+	defer o.Close()
+	o.CompactThreshold = 2
+
+	seq1, _ := o.Put(FormatMsg(&FeishuMsg{Title: "消息1"}))
+	seq2, _ := o.Put(FormatMsg(&FeishuMsg{Title: "消息2"}))
+	seq3, _ := o.Put(FormatMsg(&FeishuMsg{Title: "消息3"}))
+	_ = o.Ack(seq1)
+	_ = o.Ack(seq2) // 达到阈值，压缩后只剩消息3
+
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 || !strings.Contains(lines[0], "消息3") {
+		t.Fatalf("压缩后应该只保留未确认的消息: %s", data)
+	}
+
+	_ = o.Ack(seq3) // 全部确认，文件清空
+	data, _ = os.ReadFile(path)
+	if len(data) != 0 {
+		t.Errorf("全部确认后文件应该为空: %s", data)
+	}
+}
+
+// 测试 Flush 发送未确认的消息
+func TestOutboxFlush(t *testing.T) {
+	var received int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&received, 1)
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	o, err := OpenOutbox(filepath.Join(t.TempDir(), "outbox.log"))
+	if err != nil {
+		t.Fatalf("打开发件箱失败: %v", err)
+	}
+	defer o.Close()
+	_, _ = o.Put(FormatMsg(&FeishuMsg{Title: "消息1"}))
+	_, _ = o.Put(FormatMsg(&FeishuMsg{Title: "消息2"}))
+
+	if err := o.Flush(context.Background(), NewClient(WithHook(server.URL))); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+	if received != 2 || len(o.Pending()) != 0 {
+		t.Errorf("应该发送并确认 2 条消息，实际发送 %d 条，剩余 %d 条", received, len(o.Pending()))
+	}
+}
+
+// 测试发送队列启动时重新发送未确认的消息
+func TestDispatcherOutboxReplay(t *testing.T) {
+	var failing int32 = 1
+	var received int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		atomic.AddInt32(&received, 1)
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "outbox.log")
+	client := NewClient(WithHook(server.URL))
+
+	// 第一次运行：飞书不可用，消息发送失败
+	o, _ := OpenOutbox(path)
+	d := NewDispatcher(client, WithOutbox(o))
+	_ = d.Enqueue(context.Background(), &FeishuMsg{Title: "告警1"}, Callbacks{})
+	_ = d.Enqueue(context.Background(), &FeishuMsg{Title: "告警2"}, Callbacks{})
+	_ = d.Close(context.Background())
+	_ = o.Close()
+
+	// 第二次运行：飞书恢复，启动时重新发送
+	atomic.StoreInt32(&failing, 0)
+	o, _ = OpenOutbox(path)
+	defer o.Close()
+	if n := len(o.Pending()); n != 2 {
+		t.Fatalf("应该有 2 条未确认的消息，实际 %d 条", n)
+	}
+	var succeeded int32
+	d = NewDispatcher(client, WithOutbox(o), WithOutboxCallbacks(Callbacks{
+		OnSuccess: func(*SendResult) { atomic.AddInt32(&succeeded, 1) },
+	}))
+	_ = d.Close(context.Background())
+
+	if received != 2 || succeeded != 2 || len(o.Pending()) != 0 {
+		t.Errorf("应该重新发送 2 条消息，实际发送 %d 条，成功回调 %d 次，剩余 %d 条", received, succeeded, len(o.Pending()))
+	}
+}
+
+// 测试发送失败的消息在运行期间重新发送，不需要重启
+func TestDispatcherOutboxRetry(t *testing.T) {
+	var failures int32 = 2
+	var received int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&failures, -1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		atomic.AddInt32(&received, 1)
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	o, _ := OpenOutbox(filepath.Join(t.TempDir(), "outbox.log"))
+	defer o.Close()
+	d := NewDispatcher(NewClient(WithHook(server.URL)),
+		WithOutbox(o),
+		WithOutboxRetryInterval(10*time.Millisecond),
+	)
+	defer d.Close(context.Background())
+
+	// 重试期间不回调，最终成功后使用原来的回调
+	var failed int32
+	succeeded := make(chan struct{}, 1)
+	_ = d.Enqueue(context.Background(), &FeishuMsg{Title: "告警"}, Callbacks{
+		OnSuccess: func(*SendResult) { succeeded <- struct{}{} },
+		OnFailure: func(error) { atomic.AddInt32(&failed, 1) },
+	})
+
+	select {
+	case <-succeeded:
+	case <-time.After(2 * time.Second):
+		t.Fatal("发送失败的消息应该被重新发送")
+	}
+	if failed != 0 || received != 1 || len(o.Pending()) != 0 {
+		t.Errorf("失败回调 %d 次，发送成功 %d 条，剩余 %d 条", failed, received, len(o.Pending()))
+	}
+}
+
+// 测试不可重试的错误和达到最大次数的消息从发件箱中移除，并只回调一次
+func TestDispatcherOutboxGiveUp(t *testing.T) {
+	cases := []struct {
+		name     string
+		response func(w http.ResponseWriter)
+		requests int32
+		maxTries bool
+	}{
+		{"签名校验失败", func(w http.ResponseWriter) {
+			_, _ = w.Write([]byte(`{"code":19021,"msg":"sign match fail"}`))
+		}, 1, false},
+		{"服务不可用", func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}, 3, true},
+	}
+	for _, c := range cases {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			c.response(w)
+		}))
+
+		o, _ := OpenOutbox(filepath.Join(t.TempDir(), "outbox.log"))
+		d := NewDispatcher(NewClient(WithHook(server.URL)),
+			WithOutbox(o),
+			WithOutboxRetryInterval(10*time.Millisecond),
+			WithOutboxMaxAttempts(3),
+		)
+		failed := make(chan error, 10)
+		_ = d.Enqueue(context.Background(), &FeishuMsg{Title: "告警"}, Callbacks{
+			OnFailure: func(err error) { failed <- err },
+		})
+
+		select {
+		case err := <-failed:
+			if errors.Is(err, ErrOutboxMaxAttempts) != c.maxTries {
+				t.Errorf("%s: 错误不正确: %v", c.name, err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("%s: 应该放弃发送并回调", c.name)
+		}
+		time.Sleep(50 * time.Millisecond)
+		_ = d.Close(context.Background())
+		server.Close()
+
+		if len(failed) != 0 || requests != c.requests || len(o.Pending()) != 0 {
+			t.Errorf("%s: 多余回调 %d 次，请求 %d 次，剩余 %d 条", c.name, len(failed), requests, len(o.Pending()))
+		}
+		_ = o.Close()
+	}
+}
+
+// 测试未确认的消息多于队列长度时，创建发送队列不会阻塞
+func TestDispatcherOutboxReplayNonBlocking(t *testing.T) {
+	release := make(chan struct{})
+	var received int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		atomic.AddInt32(&received, 1)
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	o, _ := OpenOutbox(filepath.Join(t.TempDir(), "outbox.log"))
+	defer o.Close()
+	for i := 0; i < 5; i++ {
+		_, _ = o.Put(FormatMsg(&FeishuMsg{Title: "告警"}))
+	}
+
+	created := make(chan *Dispatcher)
+	go func() {
+		created <- NewDispatcher(NewClient(WithHook(server.URL)), WithOutbox(o), WithQueueSize(1))
+	}()
+	var d *Dispatcher
+	select {
+	case d = <-created:
+	case <-time.After(time.Second):
+		close(release)
+		t.Fatal("创建发送队列不应该等待重新发送")
+	}
+
+	close(release)
+	if err := d.Close(context.Background()); err != nil {
+		t.Fatalf("关闭失败: %v", err)
+	}
+	if received != 5 || len(o.Pending()) != 0 {
+		t.Errorf("应该重新发送 5 条消息，实际发送 %d 条，剩余 %d 条", received, len(o.Pending()))
+	}
+}
@@ -0,0 +1,62 @@
+package bot
+
+import "strings"
+
+/**
+ * @Description: 折叠面板
+ * 文档 https://open.feishu.cn/document/feishu-cards/card-json-v2-components/containers/collapsible-panel
+ * 堆栈、日志等较长的内容可以放在折叠面板中，默认收起，点击标题后展开
+ * 设置 FeishuMsg.Details 后自动生成一个收起的折叠面板，
+ * 堆栈中的 *、_、< 等字符会被当作 markdown 解析，因此默认放在代码块中原样显示
+ */
+
+// DefaultDetailsTitle 详细信息折叠面板的默认标题
+const DefaultDetailsTitle = "详细信息"
+
+// CreateCollapsiblePanelElement 创建带边框的折叠面板，标题使用 markdown
+func CreateCollapsiblePanelElement(title string, expanded bool, elements ...CardElement) CollapsiblePanelElement {
+	return CollapsiblePanelElement{
+		Expanded: expanded,
+		Header: CollapsiblePanelHeader{
+			Title:             &Text{Tag: "markdown", Content: title},
+			VerticalAlign:     "center",
+			Icon:              &Icon{Tag: "standard_icon", Token: "down-small-ccm_outlined", Color: "grey"},
+			IconPosition:      "right",
+			IconExpandedAngle: -180,
+		},
+		Border:   &PanelBorder{Color: "grey", CornerRadius: "5px"},
+		Elements: elements,
+	}
+}
+
+// buildDetailsElement 将详细信息放在收起的折叠面板中
+func (f *FeishuMsg) buildDetailsElement() CollapsiblePanelElement {
+	title := f.DetailsTitle
+	if title == "" {
+		title = DefaultDetailsTitle
+	}
+	details := f.Details
+	if !f.DetailsMarkdown {
+		details = codeBlock(details)
+	}
+	return CreateCollapsiblePanelElement("**"+title+"**", false, CreateMarkdownElement(details))
+}
+
+// codeBlock 将内容放在代码块中，围栏比内容中最长的连续反引号更长
+func codeBlock(content string) string {
+	longest, run := 0, 0
+	for _, r := range content {
+		if r != '`' {
+			run = 0
+			continue
+		}
+		if run++; run > longest {
+			longest = run
+		}
+	}
+	fence := strings.Repeat("`", 3)
+	if longest >= 3 {
+		fence = strings.Repeat("`", longest+1)
+	}
+	return fence + "\n" + strings.TrimRight(content, "\n") + "\n" + fence
+}
@@ -0,0 +1,104 @@
+package bot
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// 测试详细信息放在收起的折叠面板中
+func TestFeishuMsgDetails(t *testing.T) {
+	f := &FeishuMsg{
+		Title:         "服务异常",
+		MarkdownArray: [][2]string{{"错误", "空指针"}},
+		Details:       "panic: nil pointer\n*main.Server",
+		Images:        []string{"img_v2_xxx"},
+		Note:          "备注",
+	}
+	for _, schema := range []CardSchema{CardSchemaV1, CardSchemaV2} {
+		f.Schema = schema
+		msg := FormatMsg(f)
+		elements := msg.Card.Elements
+		if schema == CardSchemaV2 {
+			elements = msg.Card.Body.Elements
+		}
+		panel, ok := elements[1].(CollapsiblePanelElement)
+		if !ok || elements[2].ElementTag() != "img" {
+			t.Fatalf("折叠面板应该在内容之后、图片之前: %+v", elements)
+		}
+		if panel.Expanded || panel.Header.Title.Content != "**"+DefaultDetailsTitle+"**" {
+			t.Errorf("折叠面板不正确: %+v", panel)
+		}
+		if md := panel.Elements[0].(Element); md.Content != "```\n"+f.Details+"\n```" {
+			t.Errorf("折叠面板内容不正确: %s", md.Content)
+		}
+		if err := Validate(msg); err != nil {
+			t.Errorf("校验失败: %v", err)
+		}
+	}
+
+	f.DetailsTitle = "堆栈"
+	f.DetailsMarkdown = true
+	panel := f.buildDetailsElement()
+	if panel.Header.Title.Content != "**堆栈**" {
+		t.Errorf("自定义标题不正确: %s", panel.Header.Title.Content)
+	}
+	if md := panel.Elements[0].(Element); md.Content != f.Details {
+		t.Errorf("markdown 详细信息不应该放在代码块中: %s", md.Content)
+	}
+
+	f.Details = ""
+	for _, e := range FormatMsg(f).Card.Body.Elements {
+		if e.ElementTag() == "collapsible_panel" {
+			t.Error("没有详细信息时不应该有折叠面板")
+		}
+	}
+}
+
+// 测试详细信息中的代码块围栏
+func TestCodeBlock(t *testing.T) {
+	cases := map[string]string{
+		"a_b <c>\n":       "```\na_b <c>\n```",
+		"`x`":             "```\n`x`\n```",
+		"```go\nx\n```":   "````\n```go\nx\n```\n````",
+		"~~~\n`````\n~~~": "``````\n~~~\n`````\n~~~\n``````",
+	}
+	for in, expected := range cases {
+		if got := codeBlock(in); got != expected {
+			t.Errorf("codeBlock(%q) = %q, 期望 %q", in, got, expected)
+		}
+	}
+}
+
+// 测试折叠面板的序列化和解码
+func TestCollapsiblePanelJSON(t *testing.T) {
+	data := []byte(`{
+		"tag": "collapsible_panel",
+		"expanded": true,
+		"header": {"title": {"tag": "plain_text", "content": "日志"}, "icon_expanded_angle": -180},
+		"border": {"color": "grey", "corner_radius": "5px"},
+		"elements": [{"tag": "markdown", "content": "line1"}, {"tag": "unknown_tag"}],
+		"extra_field": 1
+	}`)
+	elem, err := UnmarshalCardElement(data)
+	if err != nil {
+		t.Fatalf("解码失败: %v", err)
+	}
+	panel, ok := elem.(CollapsiblePanelElement)
+	if !ok || !panel.Expanded || panel.Header.Title.Content != "日志" || len(panel.Elements) != 2 {
+		t.Fatalf("解码结果不正确: %+v", elem)
+	}
+	if _, ok := panel.Elements[0].(MarkdownElement); !ok {
+		t.Errorf("子组件应该按 tag 解码: %+v", panel.Elements[0])
+	}
+
+	out, err := json.Marshal(panel)
+	if err != nil {
+		t.Fatalf("序列化失败: %v", err)
+	}
+	assertJSONEqual(t, data, out)
+
+	paths := validationPaths(t, Validate(NewCardMsg(&Card{Elements: []CardElement{CollapsiblePanelElement{}}})))
+	if _, ok := paths["card.elements[0].header.title"]; !ok {
+		t.Errorf("缺少标题应该校验失败: %v", paths)
+	}
+}
@@ -0,0 +1,203 @@
+package bot
+
+import (
+	"regexp"
+	"strings"
+)
+
+/**
+ * @Description: 富文本（post）消息
+ * 文档 https://open.feishu.cn/document/client-docs/bot-v3/add-custom-bot#f62e72d5
+ * 富文本消息由标题和若干段落组成，每个段落是一组节点：
+ * text：文本，a：超链接，at：@用户，img：图片，emotion：表情
+ * 支持同时携带多种语言的内容（zh_cn、en_us、ja_jp），客户端按用户语言展示。
+ */
+
+// 富文本消息支持的语言
+const (
+	LangZhCn = "zh_cn"
+	LangEnUs = "en_us"
+	LangJaJp = "ja_jp"
+)
+
+// PostContent 富文本消息内容（多语言）
+type PostContent struct {
+	ZhCn *PostBody `json:"zh_cn,omitempty"` // 中文
+	EnUs *PostBody `json:"en_us,omitempty"` // 英文
+	JaJp *PostBody `json:"ja_jp,omitempty"` // 日文
+}
+
+// PostBody 单个语言的富文本内容
+type PostBody struct {
+	Title   string       `json:"title,omitempty"`
+	Content [][]PostNode `json:"content"` // 段落列表，每个段落是一组节点
+}
+
+// PostNode 富文本节点
+type PostNode struct {
+	Tag       string   `json:"tag"`
+	Text      string   `json:"text,omitempty"`
+	Href      string   `json:"href,omitempty"`       // a：链接地址
+	UserID    string   `json:"user_id,omitempty"`    // at：用户ID，all 表示所有人
+	UserName  string   `json:"user_name,omitempty"`  // at：用户名
+	ImageKey  string   `json:"image_key,omitempty"`  // img：图片key
+	EmojiType string   `json:"emoji_type,omitempty"` // emotion：表情类型
+	Style     []string `json:"style,omitempty"`      // 文本样式：bold、underline、lineThrough、italic
+	UnEscape  bool     `json:"un_escape,omitempty"`  // text：是否 unescape 解码
+}
+
+// PostText 构建文本节点，可指定样式（bold、underline、lineThrough、italic）
+func PostText(text string, style ...string) PostNode {
+	return PostNode{Tag: "text", Text: text, Style: style}
+}
+
+// PostLink 构建超链接节点
+func PostLink(text, href string) PostNode {
+	return PostNode{Tag: "a", Text: text, Href: href}
+}
+
+// PostAt 构建 @ 用户节点
+func PostAt(userID, name string) PostNode {
+	return PostNode{Tag: "at", UserID: userID, UserName: name}
+}
+
+// PostAtAll 构建 @ 所有人节点
+func PostAtAll() PostNode {
+	return PostNode{Tag: "at", UserID: "all", UserName: "所有人"}
+}
+
+// PostImage 构建图片节点
+func PostImage(imageKey string) PostNode {
+	return PostNode{Tag: "img", ImageKey: imageKey}
+}
+
+// PostEmotion 构建表情节点，emojiType 如 SMILE、THUMBSUP
+func PostEmotion(emojiType string) PostNode {
+	return PostNode{Tag: "emotion", EmojiType: emojiType}
+}
+
+// PostBuilder 富文本消息构建器
+type PostBuilder struct {
+	content PostContent
+	cur     *PostBody
+}
+
+// NewPost 创建富文本消息构建器
+func NewPost() *PostBuilder {
+	return &PostBuilder{}
+}
+
+// Lang 切换到指定语言并设置标题，之后的段落写入该语言
+func (b *PostBuilder) Lang(lang, title string) *PostBuilder {
+	body := b.body(lang)
+	body.Title = title
+	b.cur = body
+	return b
+}
+
+// body 获取（或创建）指定语言的内容
+func (b *PostBuilder) body(lang string) *PostBody {
+	var slot **PostBody
+	switch lang {
+	case LangEnUs:
+		slot = &b.content.EnUs
+	case LangJaJp:
+		slot = &b.content.JaJp
+	default:
+		slot = &b.content.ZhCn
+	}
+	if *slot == nil {
+		*slot = &PostBody{Content: [][]PostNode{}}
+	}
+	return *slot
+}
+
+// Line 追加一个段落，未调用 Lang 时写入中文内容
+func (b *PostBuilder) Line(nodes ...PostNode) *PostBuilder {
+	if b.cur == nil {
+		b.cur = b.body(LangZhCn)
+	}
+	b.cur.Content = append(b.cur.Content, nodes)
+	return b
+}
+
+// Build 构建富文本消息
+func (b *PostBuilder) Build() *Msg {
+	content := b.content
+	return &Msg{
+		MsgType: MsgTypePost,
+		Content: &Content{Post: &content},
+	}
+}
+
+var (
+	// fontTagPattern 富文本不支持卡片的 <font> 标签，转换时去掉
+	fontTagPattern = regexp.MustCompile(`</?font[^>]*>`)
+	// inlineMarkdownPattern 可以转换为富文本节点的行内 Markdown：链接、加粗、删除线
+	inlineMarkdownPattern = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)|\*\*(.+?)\*\*|~~(.+?)~~`)
+)
+
+// markdownPostNodes 将一行 Markdown 转换为富文本节点
+// [文本](链接) 转换为 a 节点，**加粗**、~~删除线~~ 转换为带样式的节点，其余内容原样作为文本
+func markdownPostNodes(line string, style ...string) []PostNode {
+	var nodes []PostNode
+	text := func(s string) {
+		if s != "" {
+			nodes = append(nodes, PostText(s, style...))
+		}
+	}
+	last := 0
+	for _, m := range inlineMarkdownPattern.FindAllStringSubmatchIndex(line, -1) {
+		text(line[last:m[0]])
+		switch {
+		case m[2] >= 0:
+			link := PostLink(line[m[2]:m[3]], line[m[4]:m[5]])
+			link.Style = style
+			nodes = append(nodes, link)
+		case m[6] >= 0:
+			nodes = append(nodes, markdownPostNodes(line[m[6]:m[7]], appendStyle(style, "bold")...)...)
+		case m[8] >= 0:
+			nodes = append(nodes, markdownPostNodes(line[m[8]:m[9]], appendStyle(style, "lineThrough")...)...)
+		}
+		last = m[1]
+	}
+	text(line[last:])
+	return nodes
+}
+
+// appendStyle 追加样式，不修改原切片
+func appendStyle(style []string, s string) []string {
+	return append(append([]string(nil), style...), s)
+}
+
+// FormatPostMsg 将 FeishuMsg 的键值对内容转换为富文本消息，适用于卡片展示效果不佳的群
+// 每个内容项转换为一个段落，键加粗；值中的链接、加粗、删除线转换为对应的节点；备注和链接追加在最后
+func FormatPostMsg(f *FeishuMsg) *Msg {
+	b := NewPost().Lang(LangZhCn, f.Title)
+
+	for _, item := range f.markdownEntries() {
+		lines := strings.Split(fontTagPattern.ReplaceAllString(item.Content, ""), "\n")
+		first := []PostNode{}
+		if item.Tag != "" {
+			first = append(first, PostText(item.Tag+"：", "bold"))
+		}
+		first = append(first, markdownPostNodes(lines[0])...)
+		if len(first) > 0 {
+			b.Line(first...)
+		}
+		for _, line := range lines[1:] {
+			if nodes := markdownPostNodes(line); len(nodes) > 0 {
+				b.Line(nodes...)
+			}
+		}
+	}
+
+	for _, imgKey := range f.Images {
+		b.Line(PostImage(imgKey))
+	}
+	if f.Link != "" {
+		b.Line(PostLink("查看详情", f.Link))
+	}
+	b.Line(PostText(f.buildNoteContent()))
+	return b.Build()
+}
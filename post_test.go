@@ -0,0 +1,102 @@
+package bot
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// 测试多语言富文本消息构建与序列化
+func TestPostBuilder(t *testing.T) {
+	msg := NewPost().
+		Lang(LangZhCn, "发布通知").
+		Line(PostText("版本 "), PostText("v1.2.0", "bold"), PostText(" 已发布")).
+		Line(PostLink("查看更新日志", "https://example.com/changelog"), PostAt("ou_123", "张三"), PostEmotion("THUMBSUP")).
+		Lang(LangEnUs, "Release").
+		Line(PostText("v1.2.0 released"), PostAtAll()).
+		Build()
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("序列化失败: %v", err)
+	}
+
+	var got map[string]any
+	_ = json.Unmarshal(data, &got)
+	if got["msg_type"] != MsgTypePost || got["card"] != nil {
+		t.Fatalf("消息类型不正确: %s", data)
+	}
+
+	post := msg.Content.Post
+	if post.ZhCn == nil || post.EnUs == nil {
+		t.Fatal("应该同时包含中文和英文内容")
+	}
+	if post.ZhCn.Title != "发布通知" || len(post.ZhCn.Content) != 2 {
+		t.Errorf("中文内容不正确: %+v", post.ZhCn)
+	}
+	if post.EnUs.Title != "Release" || len(post.EnUs.Content) != 1 {
+		t.Errorf("英文内容不正确: %+v", post.EnUs)
+	}
+	if !reflect.DeepEqual(post.ZhCn.Content[0][1].Style, []string{"bold"}) {
+		t.Errorf("文本样式不正确: %+v", post.ZhCn.Content[0][1])
+	}
+}
+
+// 测试 FeishuMsg 转换为富文本段落
+func TestFormatPostMsg(t *testing.T) {
+	f := &FeishuMsg{
+		Title: "部署完成",
+		MarkdownItems: []Text{
+			{Tag: "状态", Content: "<font color='green'>成功</font>"},
+			{Content: "纯文本内容"},
+		},
+		MarkdownArray: [][2]string{
+			{"列表", "\n- 步骤1\n- 步骤2"},
+		},
+		Link: "https://example.com",
+		Note: "备注",
+	}
+
+	body := FormatPostMsg(f).Content.Post.ZhCn
+	expected := [][]PostNode{
+		{PostText("状态：", "bold"), PostText("成功")},
+		{PostText("纯文本内容")},
+		{PostText("列表：", "bold")},
+		{PostText("- 步骤1")},
+		{PostText("- 步骤2")},
+		{PostLink("查看详情", "https://example.com")},
+		{PostText("备注")},
+	}
+	if body.Title != "部署完成" {
+		t.Errorf("标题不正确: %s", body.Title)
+	}
+	if !reflect.DeepEqual(body.Content, expected) {
+		t.Errorf("段落不正确:\n期望 %+v\n实际 %+v", expected, body.Content)
+	}
+}
+
+// 测试值中的 Markdown 转换为富文本节点
+func TestFormatPostMsgMarkdown(t *testing.T) {
+	f := &FeishuMsg{
+		Title: "通知",
+		MarkdownArray: [][2]string{
+			{"链接", "请查看 [飞书](https://www.feishu.cn) 文档"},
+			{"说明", "**重要**：~~旧版本~~已下线"},
+			{"混合", "**详见 [文档](https://example.com)**"},
+		},
+		Note: "备注",
+	}
+
+	body := FormatPostMsg(f).Content.Post.ZhCn
+	bold := PostLink("文档", "https://example.com")
+	bold.Style = []string{"bold"}
+	expected := [][]PostNode{
+		{PostText("链接：", "bold"), PostText("请查看 "), PostLink("飞书", "https://www.feishu.cn"), PostText(" 文档")},
+		{PostText("说明：", "bold"), PostText("重要", "bold"), PostText("："), PostText("旧版本", "lineThrough"), PostText("已下线")},
+		{PostText("混合：", "bold"), PostText("详见 ", "bold"), bold},
+		{PostText("备注")},
+	}
+	if !reflect.DeepEqual(body.Content, expected) {
+		t.Errorf("段落不正确:\n期望 %+v\n实际 %+v", expected, body.Content)
+	}
+}
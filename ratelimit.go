@@ -0,0 +1,201 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+/**
+ * @Description: 客户端令牌桶限流
+ * 自定义机器人的频率限制为 100 次/分钟、5 次/秒，在发送前进行限流，避免触发飞书的频率限制。
+ * 每条限制规则对应一个令牌桶，发送一次需要从所有令牌桶中各取一个令牌。
+ */
+
+// ErrRateLimited 非阻塞模式下超出客户端限流
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimit 限流规则：每 Per 时间内最多 Count 次请求
+type RateLimit struct {
+	Count int
+	Per   time.Duration
+}
+
+// DefaultRateLimits 飞书自定义机器人的默认频率限制
+var DefaultRateLimits = []RateLimit{
+	{Count: 5, Per: time.Second},
+	{Count: 100, Per: time.Minute},
+}
+
+// RateLimitMode 限流模式
+type RateLimitMode int
+
+const (
+	RateLimitBlock    RateLimitMode = iota // 阻塞等待直到获取令牌（默认）
+	RateLimitNonBlock                      // 无可用令牌时立即返回 ErrRateLimited
+)
+
+// bucket 单个令牌桶
+type bucket struct {
+	capacity float64   // 桶容量
+	tokens   float64   // 当前令牌数
+	rate     float64   // 每秒补充的令牌数
+	last     time.Time // 上次补充时间
+}
+
+// refill 按经过的时间补充令牌
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+	}
+	b.last = now
+}
+
+// RateLimiter 令牌桶限流器，可在多个 goroutine 中并发使用
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets []*bucket
+	now     func() time.Time
+}
+
+// NewRateLimiter 根据限流规则创建限流器，未指定规则时使用 DefaultRateLimits
+func NewRateLimiter(limits ...RateLimit) *RateLimiter {
+	if len(limits) == 0 {
+		limits = DefaultRateLimits
+	}
+
+	l := &RateLimiter{now: time.Now}
+	start := l.now()
+	for _, limit := range limits {
+		if limit.Count <= 0 || limit.Per <= 0 {
+			continue
+		}
+		l.buckets = append(l.buckets, &bucket{
+			capacity: float64(limit.Count),
+			tokens:   float64(limit.Count),
+			rate:     float64(limit.Count) / limit.Per.Seconds(),
+			last:     start,
+		})
+	}
+	return l
+}
+
+// reserve 尝试获取一个令牌，失败时返回需要等待的时间
+func (l *RateLimiter) reserve() (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var wait time.Duration
+	for _, b := range l.buckets {
+		b.refill(now)
+		if b.tokens < 1 {
+			d := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+			if d > wait {
+				wait = d
+			}
+		}
+	}
+	if wait > 0 {
+		return false, wait
+	}
+
+	for _, b := range l.buckets {
+		b.tokens--
+	}
+	return true, 0
+}
+
+// Allow 非阻塞获取令牌，成功返回 true
+func (l *RateLimiter) Allow() bool {
+	ok, _ := l.reserve()
+	return ok
+}
+
+// Wait 阻塞等待直到获取令牌，context 取消时返回错误
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		ok, wait := l.reserve()
+		if ok {
+			return nil
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// 按 hook 共享的限流器，同一进程内发往同一机器人的请求共用令牌
+var hookLimiters = struct {
+	sync.Mutex
+	m map[string]*RateLimiter
+}{m: make(map[string]*RateLimiter)}
+
+// sharedRateLimiter 获取 hook 与限流规则对应的共享限流器
+func sharedRateLimiter(hook string, limits []RateLimit) *RateLimiter {
+	if len(limits) == 0 {
+		limits = DefaultRateLimits
+	}
+
+	var key strings.Builder
+	key.WriteString(hook)
+	for _, limit := range limits {
+		key.WriteString(fmt.Sprintf("|%d/%s", limit.Count, limit.Per))
+	}
+
+	hookLimiters.Lock()
+	defer hookLimiters.Unlock()
+	if l, ok := hookLimiters.m[key.String()]; ok {
+		return l
+	}
+	l := NewRateLimiter(limits...)
+	hookLimiters.m[key.String()] = l
+	return l
+}
+
+// WithRateLimit 按 hook 限流，同一 hook 且规则相同的客户端共享令牌，未指定规则时使用 DefaultRateLimits
+func WithRateLimit(limits ...RateLimit) ClientOption {
+	return func(c *Client) {
+		if limits == nil {
+			limits = DefaultRateLimits
+		}
+		c.rateLimits = limits
+		c.limiter = nil
+	}
+}
+
+// WithRateLimiter 使用指定的限流器，传入 nil 表示不限流
+func WithRateLimiter(l *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.limiter = l
+		c.rateLimits = nil
+	}
+}
+
+// WithRateLimitMode 设置限流模式（阻塞或非阻塞）
+func WithRateLimitMode(mode RateLimitMode) ClientOption {
+	return func(c *Client) {
+		c.rateLimitMode = mode
+	}
+}
+
+// acquire 发送前获取令牌
+func (c *Client) acquire(ctx context.Context) error {
+	if c.limiter == nil {
+		return nil
+	}
+	if c.rateLimitMode == RateLimitNonBlock {
+		if !c.limiter.Allow() {
+			return ErrRateLimited
+		}
+		return nil
+	}
+	return c.limiter.Wait(ctx)
+}
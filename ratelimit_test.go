@@ -0,0 +1,149 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock 可手动推进的时钟
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestLimiter(clock *fakeClock, limits ...RateLimit) *RateLimiter {
+	l := NewRateLimiter(limits...)
+	l.now = clock.Now
+	for _, b := range l.buckets {
+		b.last = clock.Now()
+	}
+	return l
+}
+
+// 测试默认规则：每秒 5 次，每分钟 100 次
+func TestRateLimiterAllow(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l := newTestLimiter(clock)
+
+	for i := 0; i < 5; i++ {
+		if !l.Allow() {
+			t.Fatalf("第 %d 次请求应该被允许", i+1)
+		}
+	}
+	if l.Allow() {
+		t.Fatal("每秒第 6 次请求应该被拒绝")
+	}
+
+	clock.Advance(200 * time.Millisecond)
+	if !l.Allow() {
+		t.Fatal("200ms 后应该补充 1 个令牌")
+	}
+}
+
+// 测试多条规则同时生效：任一令牌桶耗尽即拒绝
+func TestRateLimiterMultipleLimits(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l := newTestLimiter(clock, RateLimit{Count: 5, Per: time.Second}, RateLimit{Count: 3, Per: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		if !l.Allow() {
+			t.Fatalf("第 %d 次请求应该被允许", i+1)
+		}
+	}
+	if l.Allow() {
+		t.Fatal("分钟级令牌耗尽后应该被拒绝")
+	}
+
+	clock.Advance(10 * time.Second)
+	if l.Allow() {
+		t.Fatal("10 秒后分钟级令牌仍不足 1 个，应该被拒绝")
+	}
+
+	clock.Advance(11 * time.Second)
+	if !l.Allow() {
+		t.Fatal("21 秒后应该补充 1 个分钟级令牌")
+	}
+}
+
+// 测试阻塞模式等待令牌，并响应 context 取消
+func TestRateLimiterWait(t *testing.T) {
+	l := NewRateLimiter(RateLimit{Count: 1, Per: 50 * time.Millisecond})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("等待令牌失败: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("3 次请求至少需要等待 100ms，实际 %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("context 取消后应该返回错误，实际是 %v", err)
+	}
+}
+
+// 测试并发安全：总放行数不超过令牌数
+func TestRateLimiterConcurrent(t *testing.T) {
+	l := NewRateLimiter(RateLimit{Count: 20, Per: time.Hour})
+
+	var allowed int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if l.Allow() {
+				atomic.AddInt32(&allowed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 20 {
+		t.Errorf("应该放行 20 次，实际 %d 次", allowed)
+	}
+}
+
+// 测试客户端非阻塞模式，以及同一 hook 的客户端共享限流器
+func TestClientRateLimitNonBlock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	limit := RateLimit{Count: 2, Per: time.Hour}
+	newClient := func() *Client {
+		return NewClient(WithHook(server.URL), WithRateLimit(limit), WithRateLimitMode(RateLimitNonBlock))
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := newClient().Send(context.Background(), &FeishuMsg{Title: "测试限流"}); err != nil {
+			t.Fatalf("第 %d 次发送应该成功: %v", i+1, err)
+		}
+	}
+	if _, err := newClient().Send(context.Background(), &FeishuMsg{Title: "测试限流"}); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("超出限流应该返回 ErrRateLimited，实际是 %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+/**
+ * @Description: 解析飞书 webhook 响应
+ * 飞书在发送失败时通常仍然返回 HTTP 200，错误信息放在响应体中，例如：
+ * {"code":19021,"msg":"sign match fail or timestamp is not within one hour from current time"}
+ * 旧版接口成功时返回 {"StatusCode":0,"StatusMessage":"success"}
+ * 常见错误码 https://open.feishu.cn/document/client-docs/bot-v3/add-custom-bot#4996824a
+ */
+
+// SendResult 飞书 webhook 响应结果
+type SendResult struct {
+	Code       int             `json:"code"`           // 业务错误码，0 表示成功
+	Msg        string          `json:"msg"`            // 错误信息
+	Data       json.RawMessage `json:"data,omitempty"` // 响应数据
+	HTTPStatus int             `json:"-"`              // HTTP 状态码
+	Raw        string          `json:"-"`              // 原始响应内容
+}
+
+// ErrorKind 飞书错误分类
+type ErrorKind int
+
+const (
+	ErrorKindUnknown         ErrorKind = iota // 未知错误
+	ErrorKindRateLimited                      // 请求频率超限
+	ErrorKindKeywordMismatch                  // 未包含自定义关键词
+	ErrorKindIPNotAllowed                     // IP 不在白名单中
+	ErrorKindSignFailure                      // 签名校验失败
+	ErrorKindTooLarge                         // 请求体过大
+)
+
+// String 返回错误分类名称
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindRateLimited:
+		return "rate_limited"
+	case ErrorKindKeywordMismatch:
+		return "keyword_mismatch"
+	case ErrorKindIPNotAllowed:
+		return "ip_not_allowed"
+	case ErrorKindSignFailure:
+		return "sign_failure"
+	case ErrorKindTooLarge:
+		return "too_large"
+	default:
+		return "unknown"
+	}
+}
+
+// 飞书错误码与分类的对应关系
+var errorCodeKinds = map[int]ErrorKind{
+	9499:   ErrorKindRateLimited, // too many request
+	11232:  ErrorKindRateLimited, // frequency limited
+	19021:  ErrorKindSignFailure, // sign match fail
+	19022:  ErrorKindIPNotAllowed,
+	19024:  ErrorKindKeywordMismatch,
+	11246:  ErrorKindTooLarge, // message content too long
+	230025: ErrorKindTooLarge,
+}
+
+// classifyError 根据错误码和错误信息判断错误分类
+func classifyError(code int, msg string) ErrorKind {
+	if kind, ok := errorCodeKinds[code]; ok {
+		return kind
+	}
+
+	// 错误码未收录时根据错误信息判断
+	lower := strings.ToLower(msg)
+	switch {
+	case strings.Contains(lower, "frequency") || strings.Contains(lower, "too many"):
+		return ErrorKindRateLimited
+	case strings.Contains(lower, "key words") || strings.Contains(lower, "keyword"):
+		return ErrorKindKeywordMismatch
+	case strings.Contains(lower, "ip not allowed"):
+		return ErrorKindIPNotAllowed
+	case strings.Contains(lower, "sign match fail"):
+		return ErrorKindSignFailure
+	case strings.Contains(lower, "too large") || strings.Contains(lower, "too long") || strings.Contains(lower, "exceed"):
+		return ErrorKindTooLarge
+	}
+	return ErrorKindUnknown
+}
+
+// APIError 飞书返回的业务错误（code 非 0）
+type APIError struct {
+	Code       int       // 业务错误码
+	Msg        string    // 错误信息
+	Kind       ErrorKind // 错误分类
+	HTTPStatus int       // HTTP 状态码
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("feishu api error: code=%d, msg=%s", e.Code, e.Msg)
+}
+
+// StatusError HTTP 状态码异常且响应体中没有业务错误码
+type StatusError struct {
+	StatusCode int    // HTTP 状态码
+	Body       string // 响应内容
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("request failed with status code %d", e.StatusCode)
+}
+
+// parseResult 解析响应内容，code 非 0 时返回 *APIError，HTTP 状态异常时返回 *StatusError
+func parseResult(status int, body []byte) (*SendResult, error) {
+	var raw struct {
+		Code          *int            `json:"code"`
+		Msg           string          `json:"msg"`
+		Data          json.RawMessage `json:"data"`
+		StatusCode    *int            `json:"StatusCode"`
+		StatusMessage string          `json:"StatusMessage"`
+	}
+
+	result := &SendResult{HTTPStatus: status, Raw: string(body)}
+	decodeErr := json.Unmarshal(body, &raw)
+	if decodeErr == nil {
+		result.Data = raw.Data
+		switch {
+		case raw.Code != nil:
+			result.Code = *raw.Code
+			result.Msg = raw.Msg
+		case raw.StatusCode != nil:
+			result.Code = *raw.StatusCode
+			result.Msg = raw.StatusMessage
+		}
+	}
+
+	if result.Code != 0 {
+		return result, &APIError{
+			Code:       result.Code,
+			Msg:        result.Msg,
+			Kind:       classifyError(result.Code, result.Msg),
+			HTTPStatus: status,
+		}
+	}
+	if status < http.StatusOK || status >= http.StatusMultipleChoices {
+		return result, &StatusError{StatusCode: status, Body: result.Raw}
+	}
+	if decodeErr != nil {
+		return result, fmt.Errorf("failed to decode response body: %w", decodeErr)
+	}
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package bot
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// 测试解析成功响应（新旧两种格式）
+func TestParseResultSuccess(t *testing.T) {
+	bodies := []string{
+		`{"code":0,"msg":"success","data":{}}`,
+		`{"StatusCode":0,"StatusMessage":"success"}`,
+	}
+	for _, body := range bodies {
+		result, err := parseResult(http.StatusOK, []byte(body))
+		if err != nil {
+			t.Fatalf("不应该返回错误: %v", err)
+		}
+		if result.Code != 0 || result.Msg != "success" {
+			t.Errorf("解析结果不正确: %+v", result)
+		}
+	}
+}
+
+// 测试 HTTP 200 但 code 非 0 时返回 APIError
+func TestParseResultAPIError(t *testing.T) {
+	cases := []struct {
+		body string
+		code int
+		kind ErrorKind
+	}{
+		{`{"code":19021,"msg":"sign match fail or timestamp is not within one hour from current time"}`, 19021, ErrorKindSignFailure},
+		{`{"code":19022,"msg":"Ip Not Allowed"}`, 19022, ErrorKindIPNotAllowed},
+		{`{"code":19024,"msg":"Key Words Not Found"}`, 19024, ErrorKindKeywordMismatch},
+		{`{"code":11232,"msg":"frequency limited psm"}`, 11232, ErrorKindRateLimited},
+		{`{"code":99999,"msg":"request body too large"}`, 99999, ErrorKindTooLarge},
+		{`{"code":12345,"msg":"something else"}`, 12345, ErrorKindUnknown},
+	}
+
+	for _, c := range cases {
+		result, err := parseResult(http.StatusOK, []byte(c.body))
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("应该返回 *APIError，实际是 %v", err)
+		}
+		if apiErr.Code != c.code || apiErr.Kind != c.kind {
+			t.Errorf("错误不正确: code=%d kind=%s，期望 code=%d kind=%s", apiErr.Code, apiErr.Kind, c.code, c.kind)
+		}
+		if result == nil || result.Code != c.code {
+			t.Errorf("应该同时返回解析结果: %+v", result)
+		}
+	}
+}
+
+// 测试 HTTP 状态码异常时返回 StatusError
+func TestParseResultStatusError(t *testing.T) {
+	_, err := parseResult(http.StatusBadGateway, []byte("bad gateway"))
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("应该返回 *StatusError，实际是 %v", err)
+	}
+	if statusErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("状态码不正确: %d", statusErr.StatusCode)
+	}
+}
+
+// 测试 SendFeishuMsg 能够识别业务错误
+func TestSendFeishuMsgAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":19021,"msg":"sign match fail"}`))
+	}))
+	defer server.Close()
+
+	f := &FeishuMsg{Title: "测试业务错误"}
+	err := SendFeishuMsg(server.URL, f)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Kind != ErrorKindSignFailure {
+		t.Fatalf("应该返回签名失败错误，实际是 %v", err)
+	}
+	if f.Response != `{"code":19021,"msg":"sign match fail"}` {
+		t.Errorf("响应内容不正确: %v", f.Response)
+	}
+}
@@ -0,0 +1,118 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+/**
+ * @Description: 发送失败自动重试
+ * 自定义机器人的频率限制为 100 次/分钟、5 次/秒，超限时返回 11232 等错误码。
+ * 以下情况会按指数退避（带随机抖动）重试：超时、连接被拒绝或断开、HTTP 5xx/429、飞书频率限制错误码。
+ * 地址无效、协议不支持、证书错误等重试也不会成功的错误直接返回。
+ */
+
+// RetryPolicy 重试策略
+type RetryPolicy struct {
+	MaxAttempts int           // 最大尝试次数（包含首次发送），小于等于 1 表示不重试
+	BaseDelay   time.Duration // 首次重试前的等待时间
+	MaxDelay    time.Duration // 单次等待时间上限
+}
+
+// DefaultRetryPolicy 默认重试策略
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// WithRetry 设置发送失败时的重试策略
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// backoff 计算第 attempt 次重试前的等待时间（attempt 从 1 开始）
+// 等待时间为 BaseDelay * 2^(attempt-1)，不超过 MaxDelay，并在 [d/2, d) 范围内随机抖动
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)))
+}
+
+// isRetryable 判断错误是否可以重试
+func isRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind == ErrorKindRateLimited || apiErr.HTTPStatus >= http.StatusInternalServerError
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return isTemporaryNetError(err)
+}
+
+// isTemporaryNetError 判断网络错误是否为超时或临时的连接错误
+// 所有请求错误都会包装为 *url.Error（实现了 net.Error），因此不能只判断 net.Error
+func isTemporaryNetError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+// sleepContext 等待指定时间，context 取消时提前返回
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,210 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// scriptedServer 按顺序返回预设的响应，超出部分返回成功
+func scriptedServer(t *testing.T, script []func(w http.ResponseWriter)) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+		if n <= len(script) {
+			script[n-1](w)
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func respondStatus(status int) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.WriteHeader(status)
+	}
+}
+
+func respondBody(body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    5 * time.Millisecond,
+}
+
+// 测试 5xx 与频率限制错误会重试直到成功
+func TestRetryUntilSuccess(t *testing.T) {
+	server, calls := scriptedServer(t, []func(w http.ResponseWriter){
+		respondStatus(http.StatusBadGateway),
+		respondBody(`{"code":11232,"msg":"frequency limited psm"}`),
+		respondStatus(http.StatusTooManyRequests),
+	})
+
+	client := NewClient(WithHook(server.URL), WithRetry(testRetryPolicy))
+	result, err := client.Send(context.Background(), &FeishuMsg{Title: "测试重试"})
+	if err != nil {
+		t.Fatalf("重试后应该成功: %v", err)
+	}
+	if result.Code != 0 {
+		t.Errorf("结果不正确: %+v", result)
+	}
+	if n := atomic.LoadInt32(calls); n != 4 {
+		t.Errorf("应该请求 4 次，实际 %d 次", n)
+	}
+}
+
+// 测试超过最大次数后返回最后一次错误
+func TestRetryExhausted(t *testing.T) {
+	server, calls := scriptedServer(t, []func(w http.ResponseWriter){
+		respondStatus(http.StatusInternalServerError),
+		respondStatus(http.StatusInternalServerError),
+		respondStatus(http.StatusInternalServerError),
+		respondStatus(http.StatusInternalServerError),
+	})
+
+	client := NewClient(WithHook(server.URL), WithRetry(testRetryPolicy))
+	_, err := client.Send(context.Background(), &FeishuMsg{Title: "测试重试耗尽"})
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("应该返回 *StatusError，实际是 %v", err)
+	}
+	if n := atomic.LoadInt32(calls); n != 4 {
+		t.Errorf("应该请求 4 次，实际 %d 次", n)
+	}
+}
+
+// 测试不可重试的错误直接返回
+func TestRetryNotRetryable(t *testing.T) {
+	server, calls := scriptedServer(t, []func(w http.ResponseWriter){
+		respondBody(`{"code":19024,"msg":"Key Words Not Found"}`),
+	})
+
+	client := NewClient(WithHook(server.URL), WithRetry(testRetryPolicy))
+	_, err := client.Send(context.Background(), &FeishuMsg{Title: "测试不重试"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Kind != ErrorKindKeywordMismatch {
+		t.Fatalf("应该返回关键词错误，实际是 %v", err)
+	}
+	if n := atomic.LoadInt32(calls); n != 1 {
+		t.Errorf("应该只请求 1 次，实际 %d 次", n)
+	}
+}
+
+// 测试网络错误会重试
+func TestRetryNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	hook := server.URL
+	server.Close()
+
+	start := time.Now()
+	client := NewClient(WithHook(hook), WithRetry(testRetryPolicy))
+	if _, err := client.Send(context.Background(), &FeishuMsg{Title: "测试网络错误"}); err == nil {
+		t.Fatal("服务已关闭，应该返回错误")
+	}
+	if time.Since(start) < 3*time.Millisecond {
+		t.Error("网络错误应该经过退避重试")
+	}
+}
+
+// 测试等待重试期间 context 取消
+func TestRetryContextCancel(t *testing.T) {
+	server, calls := scriptedServer(t, []func(w http.ResponseWriter){
+		respondStatus(http.StatusServiceUnavailable),
+		respondStatus(http.StatusServiceUnavailable),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := NewClient(WithHook(server.URL), WithRetry(RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Second,
+		MaxDelay:    time.Second,
+	}))
+	_, err := client.Send(ctx, &FeishuMsg{Title: "测试取消重试"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("应该返回 context 超时错误，实际是 %v", err)
+	}
+	if n := atomic.LoadInt32(calls); n != 1 {
+		t.Errorf("应该只请求 1 次，实际 %d 次", n)
+	}
+}
+
+// 测试退避时间范围
+func TestRetryBackoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	cases := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+	for _, c := range cases {
+		for i := 0; i < 20; i++ {
+			d := p.backoff(c.attempt)
+			if d < c.min || d >= c.max {
+				t.Fatalf("第 %d 次重试等待时间 %v 不在 [%v, %v) 范围内", c.attempt, d, c.min, c.max)
+			}
+		}
+	}
+}
+
+// 测试只重试超时和临时的连接错误
+func TestIsRetryableNetError(t *testing.T) {
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+	request := func(url string) error {
+		resp, err := client.Get(url)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	closed := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	closed.Close()
+
+	cases := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"超时", request(slow.URL), true},
+		{"连接被拒绝", request(closed.URL), true},
+		{"连接断开", &url.Error{Op: "Post", URL: "https://example.com", Err: io.ErrUnexpectedEOF}, true},
+		{"协议不支持", request("ftp://example.com"), false},
+		{"地址无效", request("http://[::1"), false},
+		{"域名不存在", &url.Error{Op: "Post", URL: "https://x.invalid", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "x.invalid", IsNotFound: true}}}, false},
+		{"取消", &url.Error{Op: "Post", URL: "https://example.com", Err: context.Canceled}, false},
+	}
+	for _, c := range cases {
+		if c.err == nil {
+			t.Fatalf("%s: 应该返回错误", c.name)
+		}
+		if got := isRetryable(c.err); got != c.retryable {
+			t.Errorf("%s: isRetryable(%v) = %v, 期望 %v", c.name, c.err, got, c.retryable)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/**
+ * @Description: 基于标签的路由规则
+ * 根据 FeishuMsg.Labels 中的标签（如 severity、service、env、team）选择发送目标。
+ * 规则条件使用 AND 连接多个比较表达式，例如：env=prod AND severity>=critical
+ * 支持的比较符：= != > >= < <=。severity 按告警级别比较，数值按大小比较，其余按字符串比较。
+ * 规则按顺序匹配，命中后停止（continue 为 true 时继续匹配），均未命中时使用 default 目标。
+ *
+ * 配置示例：
+ * {
+ *   "targets": {
+ *     "oncall": {"hook": "https://open.feishu.cn/open-apis/bot/v2/hook/xxx", "secret": "xxx"},
+ *     "ops":    {"hook": "https://open.feishu.cn/open-apis/bot/v2/hook/yyy"}
+ *   },
+ *   "rules": [
+ *     {"name": "prod-critical", "when": "env=prod AND severity>=critical", "targets": ["oncall", "ops"]}
+ *   ],
+ *   "default": ["ops"]
+ * }
+ */
+
+// ErrNoRoute 没有匹配的路由规则且未配置默认目标
+var ErrNoRoute = errors.New("no route matched")
+
+// severityLevels 告警级别，从低到高
+var severityLevels = map[string]int{
+	"debug":     0,
+	"info":      1,
+	"notice":    2,
+	"warn":      3,
+	"warning":   3,
+	"error":     4,
+	"critical":  5,
+	"alert":     6,
+	"emergency": 7,
+}
+
+// RouterConfig 路由配置，可从 JSON 加载
+type RouterConfig struct {
+	Targets map[string]Target `json:"targets"`           // 目标名称 -> 目标
+	Rules   []Rule            `json:"rules"`             // 路由规则，按顺序匹配
+	Default []string          `json:"default,omitempty"` // 未命中任何规则时的目标
+}
+
+// Rule 路由规则
+type Rule struct {
+	Name     string   `json:"name,omitempty"`
+	When     string   `json:"when"`               // 条件表达式，为空表示总是匹配
+	Targets  []string `json:"targets"`            // 命中后发送的目标名称
+	Continue bool     `json:"continue,omitempty"` // 命中后是否继续匹配后续规则
+}
+
+// condition 单个比较表达式
+type condition struct {
+	key   string
+	op    string
+	value string
+}
+
+var (
+	andSplitter  = regexp.MustCompile(`(?i)\s+AND\s+`)
+	conditionExp = regexp.MustCompile(`^\s*([\w.\-]+)\s*(>=|<=|!=|==|=|>|<)\s*(.*?)\s*$`)
+)
+
+// parseConditions 解析条件表达式
+func parseConditions(when string) ([]condition, error) {
+	if strings.TrimSpace(when) == "" {
+		return nil, nil
+	}
+
+	var conds []condition
+	for _, clause := range andSplitter.Split(strings.TrimSpace(when), -1) {
+		m := conditionExp.FindStringSubmatch(clause)
+		if m == nil {
+			return nil, fmt.Errorf("invalid condition %q", clause)
+		}
+		op := m[2]
+		if op == "==" {
+			op = "="
+		}
+		conds = append(conds, condition{key: m[1], op: op, value: strings.Trim(m[3], `"'`)})
+	}
+	return conds, nil
+}
+
+// compareLabel 比较标签值与期望值，返回 -1、0、1
+func compareLabel(key, actual, expected string) int {
+	if key == "severity" {
+		a, okA := severityLevels[strings.ToLower(actual)]
+		e, okE := severityLevels[strings.ToLower(expected)]
+		if okA && okE {
+			return compareInt(a, e)
+		}
+	}
+
+	a, errA := strconv.ParseFloat(actual, 64)
+	e, errE := strconv.ParseFloat(expected, 64)
+	if errA == nil && errE == nil {
+		switch {
+		case a < e:
+			return -1
+		case a > e:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(actual, expected)
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// match 判断标签是否满足条件
+func (c condition) match(labels map[string]string) bool {
+	actual, ok := labels[c.key]
+	if !ok {
+		return c.op == "!="
+	}
+
+	cmp := compareLabel(c.key, actual, c.value)
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// compiledRule 解析后的规则
+type compiledRule struct {
+	Rule
+	conds []condition
+}
+
+// Router 根据标签选择目标并发送消息
+type Router struct {
+	client  *Client
+	targets map[string]Target
+	rules   []compiledRule
+	def     []string
+}
+
+// ParseRouterConfig 从 JSON 解析路由配置
+func ParseRouterConfig(data []byte) (RouterConfig, error) {
+	var cfg RouterConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse router config: %w", err)
+	}
+	return cfg, nil
+}
+
+// LoadRouterConfig 从 JSON 文件加载路由配置
+func LoadRouterConfig(path string) (RouterConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return RouterConfig{}, fmt.Errorf("failed to read router config: %w", err)
+	}
+	return ParseRouterConfig(data)
+}
+
+// NewRouter 创建路由器，校验规则表达式与目标名称
+func NewRouter(client *Client, cfg RouterConfig) (*Router, error) {
+	r := &Router{
+		client:  client,
+		targets: make(map[string]Target, len(cfg.Targets)),
+		def:     cfg.Default,
+	}
+	for name, t := range cfg.Targets {
+		if t.Hook == "" {
+			return nil, fmt.Errorf("target %q: hook url is empty", name)
+		}
+		t.Name = name
+		r.targets[name] = t
+	}
+
+	for i, rule := range cfg.Rules {
+		conds, err := parseConditions(rule.When)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d (%s): %w", i, rule.Name, err)
+		}
+		if err := r.checkTargets(rule.Targets); err != nil {
+			return nil, fmt.Errorf("rule %d (%s): %w", i, rule.Name, err)
+		}
+		r.rules = append(r.rules, compiledRule{Rule: rule, conds: conds})
+	}
+	if err := r.checkTargets(cfg.Default); err != nil {
+		return nil, fmt.Errorf("default: %w", err)
+	}
+	return r, nil
+}
+
+// checkTargets 检查目标名称是否已定义
+func (r *Router) checkTargets(names []string) error {
+	for _, name := range names {
+		if _, ok := r.targets[name]; !ok {
+			return fmt.Errorf("unknown target %q", name)
+		}
+	}
+	return nil
+}
+
+// Route 根据标签返回匹配的目标（去重并保持顺序）
+func (r *Router) Route(labels map[string]string) []Target {
+	var names []string
+	matched := false
+
+rules:
+	for _, rule := range r.rules {
+		for _, c := range rule.conds {
+			if !c.match(labels) {
+				continue rules
+			}
+		}
+		matched = true
+		names = append(names, rule.Targets...)
+		if !rule.Continue {
+			break
+		}
+	}
+	if !matched {
+		names = r.def
+	}
+
+	seen := make(map[string]bool, len(names))
+	targets := make([]Target, 0, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		targets = append(targets, r.targets[name])
+	}
+	return targets
+}
+
+// Send 根据 f.Labels 选择目标并发送，没有匹配的目标时返回 ErrNoRoute
+func (r *Router) Send(ctx context.Context, f *FeishuMsg) (map[string]*TargetResult, error) {
+	targets := r.Route(f.Labels)
+	if len(targets) == 0 {
+		return nil, ErrNoRoute
+	}
+	return r.client.Broadcast(ctx, targets, f)
+}
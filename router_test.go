@@ -0,0 +1,143 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testRouterConfig = `{
+	"targets": {
+		"oncall": {"hook": "https://example.com/hook/oncall"},
+		"ops":    {"hook": "https://example.com/hook/ops"},
+		"dev":    {"hook": "https://example.com/hook/dev"},
+		"pay":    {"hook": "https://example.com/hook/pay"}
+	},
+	"rules": [
+		{"name": "prod-critical", "when": "env=prod AND severity>=critical", "targets": ["oncall", "ops"], "continue": true},
+		{"name": "payment", "when": "service = payment", "targets": ["pay", "ops"]},
+		{"name": "non-prod", "when": "env != prod", "targets": ["dev"]}
+	],
+	"default": ["ops"]
+}`
+
+func targetNames(targets []Target) string {
+	names := make([]string, 0, len(targets))
+	for _, t := range targets {
+		names = append(names, t.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// 测试路由规则匹配
+func TestRouterRoute(t *testing.T) {
+	cfg, err := ParseRouterConfig([]byte(testRouterConfig))
+	if err != nil {
+		t.Fatalf("解析配置失败: %v", err)
+	}
+	r, err := NewRouter(NewClient(), cfg)
+	if err != nil {
+		t.Fatalf("创建路由器失败: %v", err)
+	}
+
+	cases := []struct {
+		labels   map[string]string
+		expected string
+	}{
+		{map[string]string{"env": "prod", "severity": "critical"}, "oncall,ops"},
+		{map[string]string{"env": "prod", "severity": "emergency", "service": "payment"}, "oncall,ops,pay"},
+		{map[string]string{"env": "prod", "severity": "warning"}, "ops"},
+		{map[string]string{"env": "prod", "severity": "warning", "service": "payment"}, "pay,ops"},
+		{map[string]string{"env": "test", "severity": "critical"}, "dev"},
+		{nil, "dev"},
+	}
+	for _, c := range cases {
+		if got := targetNames(r.Route(c.labels)); got != c.expected {
+			t.Errorf("标签 %v 应该路由到 %s，实际 %s", c.labels, c.expected, got)
+		}
+	}
+}
+
+// 测试条件表达式比较
+func TestConditionMatch(t *testing.T) {
+	cases := []struct {
+		when   string
+		labels map[string]string
+		match  bool
+	}{
+		{"severity>=error", map[string]string{"severity": "ERROR"}, true},
+		{"severity>error", map[string]string{"severity": "warn"}, false},
+		{"latency_ms > 500", map[string]string{"latency_ms": "1200"}, true},
+		{"latency_ms > 500", map[string]string{"latency_ms": "90"}, false},
+		{`team == "infra" AND env=prod`, map[string]string{"team": "infra", "env": "prod"}, true},
+		{"team=infra and env=prod", map[string]string{"team": "infra"}, false},
+		{"", nil, true},
+	}
+	for _, c := range cases {
+		conds, err := parseConditions(c.when)
+		if err != nil {
+			t.Fatalf("解析条件 %q 失败: %v", c.when, err)
+		}
+		got := true
+		for _, cond := range conds {
+			got = got && cond.match(c.labels)
+		}
+		if got != c.match {
+			t.Errorf("条件 %q 对标签 %v 的匹配结果应该是 %v", c.when, c.labels, c.match)
+		}
+	}
+}
+
+// 测试配置校验
+func TestNewRouterInvalidConfig(t *testing.T) {
+	cfgs := []RouterConfig{
+		{Targets: map[string]Target{"ops": {Hook: "https://example.com"}}, Rules: []Rule{{When: "env", Targets: []string{"ops"}}}},
+		{Targets: map[string]Target{"ops": {Hook: "https://example.com"}}, Rules: []Rule{{When: "env=prod", Targets: []string{"missing"}}}},
+		{Targets: map[string]Target{"ops": {}}},
+	}
+	for _, cfg := range cfgs {
+		if _, err := NewRouter(NewClient(), cfg); err == nil {
+			t.Errorf("无效配置应该返回错误: %+v", cfg)
+		}
+	}
+}
+
+// 测试按路由发送
+func TestRouterSend(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	r, err := NewRouter(NewClient(), RouterConfig{
+		Targets: map[string]Target{
+			"oncall": {Hook: server.URL + "/oncall"},
+		},
+		Rules: []Rule{{When: "severity>=critical", Targets: []string{"oncall"}}},
+	})
+	if err != nil {
+		t.Fatalf("创建路由器失败: %v", err)
+	}
+
+	f := &FeishuMsg{Title: "数据库故障", Labels: map[string]string{"severity": "critical"}}
+	if _, err := r.Send(context.Background(), f); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+	if len(paths) != 1 || paths[0] != "/oncall" {
+		t.Errorf("应该发送到 oncall，实际 %v", paths)
+	}
+
+	f.Labels["severity"] = "info"
+	if _, err := r.Send(context.Background(), f); !errors.Is(err, ErrNoRoute) {
+		t.Errorf("没有匹配规则时应该返回 ErrNoRoute，实际是 %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package bot
+
+import "encoding/json"
+
+/**
+ * @Description: 卡片 JSON 2.0 结构
+ * 文档 https://open.feishu.cn/document/feishu-cards/card-json-v2-structure
+ * 与 1.0 结构的主要区别：
+ * 1. 顶层声明 "schema":"2.0"，组件放在 body.elements 中，body 支持 direction、padding、vertical_spacing 等布局属性
+ * 2. config 使用 width_mode 代替 wide_screen_mode，update_multi 仅支持 true，新增 summary、streaming_mode
+ * 3. 不再支持 note 备注组件和 action 交互模块，按钮直接作为组件使用，通过 behaviors 配置交互
+ */
+
+// CardSchema 卡片结构版本
+type CardSchema string
+
+const (
+	CardSchemaV1 CardSchema = "1.0" // 卡片 JSON 1.0 结构（默认）
+	CardSchemaV2 CardSchema = "2.0" // 卡片 JSON 2.0 结构
+)
+
+// CardBody 卡片正文（卡片2.0）
+type CardBody struct {
+	Direction         string        `json:"direction,omitempty"`          // 排列方向：vertical、horizontal
+	Padding           string        `json:"padding,omitempty"`            // 内边距，如 "12px 12px 12px 12px"
+	HorizontalSpacing string        `json:"horizontal_spacing,omitempty"` // 水平间距
+	HorizontalAlign   string        `json:"horizontal_align,omitempty"`   // 水平对齐方式
+	VerticalSpacing   string        `json:"vertical_spacing,omitempty"`   // 垂直间距
+	VerticalAlign     string        `json:"vertical_align,omitempty"`     // 垂直对齐方式
+	Elements          []CardElement `json:"elements"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// Summary 会话列表中的卡片摘要（卡片2.0）
+type Summary struct {
+	Content string `json:"content"`
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// Behavior 按钮等组件的交互行为（卡片2.0）
+type Behavior struct {
+	Type       string `json:"type"`                  // open_url、callback
+	DefaultUrl string `json:"default_url,omitempty"` // open_url：默认跳转链接
+	AndroidUrl string `json:"android_url,omitempty"`
+	IosUrl     string `json:"ios_url,omitempty"`
+	PCUrl      string `json:"pc_url,omitempty"`
+	Value      any    `json:"value,omitempty"` // callback：回传数据
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// MarshalJSON 按 Schema 输出卡片 1.0 或 2.0 结构
+func (c Card) MarshalJSON() ([]byte, error) {
+	var header *Header
+	if !c.Header.isZero() {
+		header = &c.Header
+	}
+
+	var data []byte
+	var err error
+	if c.Schema == string(CardSchemaV2) {
+		body := CardBody{Elements: c.Elements} // 兼容直接设置 Elements 的写法
+		if c.Body != nil {
+			body = *c.Body
+		}
+		data, err = json.Marshal(struct {
+			Schema   string    `json:"schema"`
+			Config   *Config   `json:"config,omitempty"`
+			CardLink *CardLink `json:"card_link,omitempty"`
+			Header   *Header   `json:"header,omitempty"`
+			Body     CardBody  `json:"body"`
+		}{c.Schema, c.Config, c.CardLink, header, body})
+	} else {
+		var elements any
+		if c.Elements != nil {
+			elements = c.Elements
+		}
+		data, err = json.Marshal(struct {
+			Config       *Config       `json:"config,omitempty"`
+			Header       *Header       `json:"header,omitempty"`
+			Elements     any           `json:"elements,omitempty"`
+			CardLink     *CardLink     `json:"card_link,omitempty"`
+			I18nElements *I18nElements `json:"i18n_elements,omitempty"`
+		}{c.Config, header, elements, c.CardLink, c.I18nElements})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return appendUnknown(data, c.Unknown)
+}
+
+// MarshalJSON 输出卡片正文，Elements 为空时输出 []
+func (b CardBody) MarshalJSON() ([]byte, error) {
+	type body CardBody
+	if b.Elements == nil {
+		b.Elements = []CardElement{}
+	}
+	data, err := json.Marshal(body(b))
+	if err != nil {
+		return nil, err
+	}
+	return appendUnknown(data, b.Unknown)
+}
+
+// createConfigV2 构建卡片2.0全局配置
+func createConfigV2(f *FeishuMsg) *Config {
+	config := &Config{
+		UpdateMulti:   true,
+		EnableForward: f.EnableForward,
+	}
+	if f.WideScreen {
+		config.WidthMode = "fill"
+	}
+	if f.Summary != "" {
+		config.Summary = &Summary{Content: f.Summary}
+	}
+	return config
+}
+
+// createNoteElementV2 卡片2.0不支持 note 组件，使用小号字体的 markdown 代替
+func createNoteElementV2(content string) MarkdownElement {
+	return MarkdownElement{
+		TextAlign: "left",
+		TextSize:  "notation",
+		Content:   content,
+	}
+}
+
+// createButtonV2 将 Action 转换为卡片2.0按钮组件
+func createButtonV2(a Action) ButtonElement {
+	button := ButtonElement{
+		Text:    a.Text,
+		Type:    a.Type,
+		Confirm: a.Confirm,
+	}
+	if a.Url != "" {
+		button.Behaviors = append(button.Behaviors, Behavior{Type: "open_url", DefaultUrl: a.Url})
+	}
+	if a.Value != nil {
+		button.Behaviors = append(button.Behaviors, Behavior{Type: "callback", Value: a.Value})
+	}
+	return button
+}
+
+// createButtonsElementV2 卡片2.0不支持 action 模块，使用多列布局横向排列按钮
+func createButtonsElementV2(actions []Action) ColumnSetElement {
+	columns := make([]Column, 0, len(actions))
+	for _, a := range actions {
+		columns = append(columns, Column{
+			Tag:      "column",
+			Width:    "auto",
+			Elements: []CardElement{createButtonV2(a)},
+		})
+	}
+	return ColumnSetElement{
+		FlexMode:          "flow",
+		HorizontalSpacing: "default",
+		Columns:           columns,
+	}
+}
+
+// WithCardSchema 设置 Send 时使用的卡片结构版本（FeishuMsg.Schema 未设置时生效）
+func WithCardSchema(schema CardSchema) ClientOption {
+	return func(c *Client) {
+		c.schema = schema
+	}
+}
+
+// withDefaults 按客户端配置补全 FeishuMsg，不修改 f
+func (c *Client) withDefaults(f *FeishuMsg) *FeishuMsg {
+	if f.Schema == "" && c.schema != "" {
+		ff := *f
+		ff.Schema = c.schema
+		return &ff
+	}
+	return f
+}
+
+// format 按客户端配置格式化 FeishuMsg，不修改 f
+func (c *Client) format(f *FeishuMsg) *Msg {
+	return FormatMsg(c.withDefaults(f))
+}
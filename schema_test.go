@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// decodeCard 序列化消息并返回 card 部分
+func decodeCard(t *testing.T, msg *Msg) map[string]any {
+	t.Helper()
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("序列化失败: %v", err)
+	}
+	var got struct {
+		Card map[string]any `json:"card"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	return got.Card
+}
+
+// 测试卡片2.0结构输出
+func TestFormatMsgSchemaV2(t *testing.T) {
+	card := decodeCard(t, FormatMsg(&FeishuMsg{
+		Title:         "部署通知",
+		MarkdownArray: [][2]string{{"状态", "成功"}},
+		Note:          "备注",
+		WideScreen:    true,
+		Summary:       "部署成功",
+		Schema:        CardSchemaV2,
+		Actions: []Action{
+			{Text: &Text{Tag: "plain_text", Content: "查看"}, Url: "https://example.com", Type: "primary"},
+			{Text: &Text{Tag: "plain_text", Content: "确认"}, Value: map[string]string{"action": "ack"}},
+		},
+	}))
+
+	if card["schema"] != "2.0" {
+		t.Errorf("schema 不正确: %v", card["schema"])
+	}
+	if _, ok := card["elements"]; ok {
+		t.Error("卡片2.0不应该输出顶层 elements")
+	}
+
+	config := card["config"].(map[string]any)
+	if config["update_multi"] != true || config["width_mode"] != "fill" {
+		t.Errorf("config 不正确: %v", config)
+	}
+	if _, ok := config["wide_screen_mode"]; ok {
+		t.Error("卡片2.0不应该输出 wide_screen_mode")
+	}
+	if summary, _ := config["summary"].(map[string]any); summary["content"] != "部署成功" {
+		t.Errorf("摘要不正确: %v", config["summary"])
+	}
+
+	elements := card["body"].(map[string]any)["elements"].([]any)
+	tags := make([]string, 0, len(elements))
+	for _, e := range elements {
+		tags = append(tags, e.(map[string]any)["tag"].(string))
+	}
+	expected := []string{"markdown", "column_set", "markdown"}
+	if len(tags) != len(expected) {
+		t.Fatalf("组件不正确: 期望 %v, 实际 %v", expected, tags)
+	}
+	for i := range expected {
+		if tags[i] != expected[i] {
+			t.Fatalf("组件不正确: 期望 %v, 实际 %v", expected, tags)
+		}
+	}
+
+	note := elements[2].(map[string]any)
+	if note["text_size"] != "notation" || note["content"] != "备注" {
+		t.Errorf("备注不正确: %v", note)
+	}
+}
+
+// 测试卡片2.0按钮转换为 behaviors
+func TestCardV2Buttons(t *testing.T) {
+	elem := createButtonsElementV2([]Action{
+		{Text: &Text{Tag: "plain_text", Content: "查看"}, Url: "https://example.com"},
+		{Text: &Text{Tag: "plain_text", Content: "确认"}, Value: "ack"},
+	})
+	if elem.FlexMode != "flow" || len(elem.Columns) != 2 {
+		t.Fatalf("按钮布局不正确: %+v", elem)
+	}
+
+	open, ok := elem.Columns[0].Elements[0].(ButtonElement)
+	if !ok || len(open.Behaviors) != 1 || open.Behaviors[0].Type != "open_url" || open.Behaviors[0].DefaultUrl != "https://example.com" {
+		t.Errorf("跳转按钮不正确: %+v", open)
+	}
+	callback, ok := elem.Columns[1].Elements[0].(ButtonElement)
+	if !ok || len(callback.Behaviors) != 1 || callback.Behaviors[0].Type != "callback" || callback.Behaviors[0].Value != "ack" {
+		t.Errorf("回传按钮不正确: %+v", callback)
+	}
+}
+
+// 测试默认仍输出卡片1.0结构
+func TestFormatMsgSchemaV1(t *testing.T) {
+	card := decodeCard(t, FormatMsg(&FeishuMsg{Title: "标题", Note: "备注"}))
+	if _, ok := card["schema"]; ok {
+		t.Error("卡片1.0不应该输出 schema")
+	}
+	if _, ok := card["body"]; ok {
+		t.Error("卡片1.0不应该输出 body")
+	}
+	if _, ok := card["elements"]; !ok {
+		t.Error("卡片1.0应该输出 elements")
+	}
+}
+
+// 测试直接设置 Elements 的卡片2.0
+func TestCardV2Elements(t *testing.T) {
+	card := decodeCard(t, &Msg{
+		MsgType: MsgTypeInteractive,
+		Card:    Card{Schema: "2.0", Elements: []CardElement{CreateMarkdownElement("内容")}},
+	})
+	elements := card["body"].(map[string]any)["elements"].([]any)
+	if len(elements) != 1 {
+		t.Errorf("组件不正确: %v", card)
+	}
+
+	card = decodeCard(t, &Msg{MsgType: MsgTypeInteractive, Card: Card{Schema: "2.0"}})
+	if elements, ok := card["body"].(map[string]any)["elements"].([]any); !ok || len(elements) != 0 {
+		t.Errorf("空组件应该输出 []: %v", card)
+	}
+}
+
+// 测试通过 WithCardSchema 设置客户端默认结构
+func TestClientWithCardSchema(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	f := &FeishuMsg{Title: "标题"}
+	client := NewClient(WithHook(server.URL), WithCardSchema(CardSchemaV2))
+	if _, err := client.Send(context.Background(), f); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+	if f.Schema != "" {
+		t.Error("Send 不应该修改 FeishuMsg")
+	}
+
+	var got struct {
+		Card map[string]any `json:"card"`
+	}
+	_ = json.Unmarshal(body, &got)
+	if got.Card["schema"] != "2.0" {
+		t.Errorf("应该使用卡片2.0结构: %s", body)
+	}
+}
@@ -0,0 +1,49 @@
+package bot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+/**
+ * @Description: 自定义机器人签名校验
+ * 文档 https://open.feishu.cn/document/client-docs/bot-v3/add-custom-bot#3c6592d6
+ * 开启"签名校验"后，请求体中需要携带 timestamp 与 sign 字段：
+ * 以 timestamp + "\n" + 密钥 作为 HMAC-SHA256 的签名字符串（作为 key，数据为空），
+ * 再对结果做 Base64 编码得到 sign。timestamp 与服务器时间相差不能超过 1 小时。
+ */
+
+// GenSign 根据密钥和时间戳（秒）生成签名
+func GenSign(secret string, timestamp int64) (string, error) {
+	stringToSign := fmt.Sprintf("%d\n%s", timestamp, secret)
+
+	h := hmac.New(sha256.New, []byte(stringToSign))
+	if _, err := h.Write(nil); err != nil {
+		return "", fmt.Errorf("failed to sign message: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
+
+// SetSignAt 使用指定时间为消息签名，写入 timestamp 与 sign 字段
+func (m *Msg) SetSignAt(secret string, t time.Time) error {
+	timestamp := t.Unix()
+	sign, err := GenSign(secret, timestamp)
+	if err != nil {
+		return err
+	}
+	m.Timestamp = strconv.FormatInt(timestamp, 10)
+	m.Sign = sign
+	return nil
+}
+
+// SetSign 使用当前时间为消息签名，密钥为空时不做处理
+func (m *Msg) SetSign(secret string) error {
+	if secret == "" {
+		return nil
+	}
+	return m.SetSignAt(secret, time.Now())
+}
@@ -0,0 +1,62 @@
+package bot
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 测试签名 - 已知向量
+func TestGenSign(t *testing.T) {
+	cases := []struct {
+		secret    string
+		timestamp int64
+		expected  string
+	}{
+		{"demo_secret", 1599360473, "sdR0eS36UO/d4NpAyDr6RpCJWLJtcheypjGCjiZTm0o="},
+		{"abc123", 1700000000, "J0suvZQ7pBIXibHw5hyQvuZiXVa3ct5lilLN472FoLk="},
+	}
+
+	for _, c := range cases {
+		sign, err := GenSign(c.secret, c.timestamp)
+		if err != nil {
+			t.Fatalf("生成签名失败: %v", err)
+		}
+		if sign != c.expected {
+			t.Errorf("签名不正确，期望 %s，实际 %s", c.expected, sign)
+		}
+	}
+}
+
+// 测试消息签名后序列化包含 timestamp 与 sign
+func TestMsgSetSign(t *testing.T) {
+	msg := FormatMsg(&FeishuMsg{Title: "测试签名", Note: "签名"})
+	if err := msg.SetSignAt("demo_secret", time.Unix(1599360473, 0)); err != nil {
+		t.Fatalf("签名失败: %v", err)
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("序列化失败: %v", err)
+	}
+	if !strings.Contains(string(data), `"timestamp":"1599360473"`) {
+		t.Errorf("应该包含 timestamp 字段: %s", data)
+	}
+	if !strings.Contains(string(data), `"sign":"sdR0eS36UO/d4NpAyDr6RpCJWLJtcheypjGCjiZTm0o="`) {
+		t.Errorf("应该包含 sign 字段: %s", data)
+	}
+}
+
+// 测试未设置密钥时不签名
+func TestMsgSetSignEmptySecret(t *testing.T) {
+	msg := FormatMsg(&FeishuMsg{Title: "测试无签名", Note: "无签名"})
+	if err := msg.SetSign(""); err != nil {
+		t.Fatalf("签名失败: %v", err)
+	}
+
+	data, _ := json.Marshal(msg)
+	if strings.Contains(string(data), `"sign"`) || strings.Contains(string(data), `"timestamp"`) {
+		t.Errorf("未设置密钥时不应该包含签名字段: %s", data)
+	}
+}
@@ -0,0 +1,212 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+/**
+ * @Description: 超长内容拆分为多张卡片
+ * 内容超过 MaxCardSize 时按内容项拆分，每张卡片的标题追加 (1/3) 这样的序号，
+ * 每个内容项作为一个整体并保留键，按 Layout 并排展示；单个内容项超出大小时再按 Markdown 块拆分：
+ * 1. 代码块（``` 或 ~~~ 包裹）作为一个整体，不会被拆开，包括紧跟在 **键**： 后面的代码块
+ * 2. 列表项连同其缩进的续行、子列表作为一个整体
+ * 3. 其余内容按行拆分
+ * 默认布局设置 MaxBodyRunes 时，截断后的内容不再对应内容项，按 Markdown 块拆分
+ * 表格、图表、详细信息、图片和按钮只放在最后一张卡片中，备注每张卡片都有
+ */
+
+var (
+	listItemPattern = regexp.MustCompile(`^\s*([-*+]|\d+[.)])\s`)
+	// entryPrefixPattern formatMarkdownEntry 输出的键前缀
+	entryPrefixPattern = regexp.MustCompile(`^\*\*[^\n]*?\*\*：`)
+)
+
+// FormatMsgs 构造消息卡片，请求体超过 MaxCardSize 时将内容拆分为多张卡片
+func FormatMsgs(f *FeishuMsg) []*Msg {
+	msg := FormatMsg(f)
+	if payloadSize(msg) <= MaxCardSize {
+		return []*Msg{msg}
+	}
+
+	// 除内容外的卡片大小，按最长的序号估算
+	skeleton := *f
+	skeleton.Title = f.Title + " (999/999)"
+	skeleton.Markdown, skeleton.MarkdownItems, skeleton.MarkdownArray = nil, []Text{{Content: " "}}, nil
+	budget := MaxCardSize - payloadSize(FormatMsg(&skeleton))
+
+	var entries []Text
+	if f.MaxBodyRunes > 0 && f.Layout == LayoutMarkdown {
+		// 全部内容截断后不再对应内容项，按截断后的内容拆分
+		for _, block := range markdownBlocks(f.buildMarkdownContent()) {
+			entries = append(entries, Text{Content: strings.TrimSuffix(block, "\n")})
+		}
+	} else {
+		entries = f.entryBlocks(budget)
+	}
+	chunks := splitBlocks(entries, budget, f.entrySize)
+	if len(chunks) <= 1 {
+		return []*Msg{msg}
+	}
+
+	msgs := make([]*Msg, 0, len(chunks))
+	for i, chunk := range chunks {
+		part := *f
+		part.Title = fmt.Sprintf("%s (%d/%d)", f.Title, i+1, len(chunks))
+		part.Markdown, part.MarkdownArray = nil, nil
+		part.MarkdownItems = chunk
+		// 内容已经按 MaxValueRunes、MaxBodyRunes 截断，不能再对整段截断
+		part.MaxValueRunes, part.MaxBodyRunes = 0, 0
+		if i < len(chunks)-1 {
+			part.Tables, part.Charts, part.Images, part.Actions = nil, nil, nil, nil
+			part.Details = ""
+		}
+		msgs = append(msgs, FormatMsg(&part))
+	}
+	return msgs
+}
+
+// payloadSize 返回消息序列化后的字节数
+func payloadSize(msg *Msg) int {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// jsonSize 返回字符串在 JSON 中转义后的字节数（不含引号）
+func jsonSize(s string) int {
+	data, _ := json.Marshal(s)
+	return len(data) - 2
+}
+
+// entrySize 估算内容项在卡片中占用的字节数
+// 其他布局中每个内容项按单独的组件估算，合并到同一组件时只会更小
+func (f *FeishuMsg) entrySize(item Text) int {
+	if f.Layout == LayoutMarkdown {
+		return jsonSize(formatMarkdownEntry(item))
+	}
+	var element any = CreateMarkdownElement(formatMarkdownEntry(item))
+	if f.isShortEntry(item) {
+		element = createShortElements(f.shortLayout(), []Text{item})
+	}
+	data, _ := json.Marshal(element)
+	return len(data)
+}
+
+// entryBlocks 将内容项转换为不可分割的块，每个内容项作为一个块，
+// 超出 budget 的内容项按值中的 Markdown 块拆分，第一个块保留键
+func (f *FeishuMsg) entryBlocks(budget int) []Text {
+	var blocks []Text
+	for _, item := range f.contentEntries() {
+		if f.entrySize(item) <= budget {
+			blocks = append(blocks, item)
+			continue
+		}
+		for i, part := range markdownBlocks(item.Content + "\n") {
+			block := Text{Content: strings.TrimSuffix(part, "\n")}
+			if i == 0 {
+				block.Tag = item.Tag
+			}
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// splitBlocks 将块按顺序合并为多段，每段的大小之和不超过 budget 字节
+// 单个块超过 budget 时单独成段，不会拆开
+func splitBlocks(blocks []Text, budget int, size func(Text) int) [][]Text {
+	var chunks [][]Text
+	var chunk []Text
+	total := 0
+	for _, block := range blocks {
+		n := size(block)
+		if total > 0 && total+n > budget {
+			chunks = append(chunks, chunk)
+			chunk, total = nil, 0
+		}
+		chunk = append(chunk, block)
+		total += n
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// markdownBlocks 将内容拆分为不可分割的块，每个块包含结尾的换行
+func markdownBlocks(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var blocks []string
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		end := i + 1
+
+		switch fence := fenceMarker(entryPrefixPattern.ReplaceAllString(line, "")); {
+		case fence != "":
+			// 代码块：到相同的结束标记为止，未闭合时包含剩余所有行
+			for end < len(lines) && fenceMarker(lines[end]) != fence {
+				end++
+			}
+			if end < len(lines) {
+				end++
+			}
+		case listItemPattern.MatchString(line):
+			// 列表项：包含后续缩进的续行和子列表
+			indent := len(line) - len(strings.TrimLeft(line, " \t"))
+			for end < len(lines) {
+				next := lines[end]
+				if strings.TrimSpace(next) == "" || len(next)-len(strings.TrimLeft(next, " \t")) <= indent {
+					break
+				}
+				end++
+			}
+		}
+
+		blocks = append(blocks, strings.Join(lines[i:end], ""))
+		i = end
+	}
+	return blocks
+}
+
+// fenceMarker 返回行首的代码块标记（``` 或 ~~~），不是代码块标记或在同一行闭合时返回空
+func fenceMarker(line string) string {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "```") && !strings.HasPrefix(trimmed, "~~~") {
+		return ""
+	}
+	if fence := trimmed[:3]; !strings.Contains(trimmed[3:], fence) {
+		return fence
+	}
+	return ""
+}
+
+// SendSplit 发送 FeishuMsg，内容过长时拆分为多张卡片按顺序发送，遇到错误时停止
+func (c *Client) SendSplit(ctx context.Context, f *FeishuMsg) ([]*SendResult, error) {
+	msgs := FormatMsgs(c.withDefaults(f))
+	results := make([]*SendResult, 0, len(msgs))
+	for i, msg := range msgs {
+		result, err := c.SendMsg(ctx, msg)
+		if err != nil {
+			return results, fmt.Errorf("failed to send card %d of %d: %w", i+1, len(msgs), err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// SendFeishuMsgSplit 发送消息到飞书，内容过长时拆分为多张卡片按顺序发送
+func SendFeishuMsgSplit(hook string, f *FeishuMsg, opts ...ClientOption) error {
+	opts = append([]ClientOption{WithHook(hook), WithRateLimit()}, opts...)
+	_, err := NewClient(opts...).SendSplit(context.Background(), f)
+	return err
+}
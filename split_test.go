@@ -0,0 +1,238 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// cardMarkdown 返回卡片中第一个 Markdown 组件的内容
+func cardMarkdown(t *testing.T, msg *Msg) string {
+	t.Helper()
+	elem, ok := msg.Card.Elements[0].(Element)
+	if !ok || elem.Tag != "markdown" {
+		t.Fatalf("第一个组件应该是 markdown: %#v", msg.Card.Elements[0])
+	}
+	return elem.Content
+}
+
+// 测试内容较短时不拆分
+func TestFormatMsgsSingle(t *testing.T) {
+	msgs := FormatMsgs(&FeishuMsg{Title: "日报", MarkdownArray: [][2]string{{"状态", "正常"}}})
+	if len(msgs) != 1 || msgs[0].Card.Header.Title.Content != "日报" {
+		t.Errorf("不应该拆分: %d", len(msgs))
+	}
+}
+
+// 测试超长内容拆分为多张卡片
+func TestFormatMsgsSplit(t *testing.T) {
+	f := &FeishuMsg{
+		Title:   "日报",
+		Note:    "备注",
+		Actions: []Action{CreateButtonElement("查看", "https://example.com")},
+	}
+	for i := 0; i < 600; i++ {
+		f.MarkdownArray = append(f.MarkdownArray, [2]string{fmt.Sprintf("服务%03d", i), strings.Repeat("正常", 20)})
+	}
+
+	msgs := FormatMsgs(f)
+	if len(msgs) < 2 {
+		t.Fatalf("应该拆分为多张卡片，实际 %d 张", len(msgs))
+	}
+
+	var content strings.Builder
+	for i, msg := range msgs {
+		if size := payloadSize(msg); size > MaxCardSize {
+			t.Errorf("第 %d 张卡片超出大小限制: %d", i+1, size)
+		}
+		expected := fmt.Sprintf("日报 (%d/%d)", i+1, len(msgs))
+		if title := msg.Card.Header.Title.Content; title != expected {
+			t.Errorf("标题不正确: 期望 %s, 实际 %s", expected, title)
+		}
+
+		last := msg.Card.Elements[len(msg.Card.Elements)-1].(Element)
+		if last.Tag != "note" {
+			t.Errorf("第 %d 张卡片应该有备注", i+1)
+		}
+		hasAction := msg.Card.Elements[1].(Element).Tag == "action"
+		if hasAction != (i == len(msgs)-1) {
+			t.Errorf("按钮应该只在最后一张卡片中: 第 %d 张", i+1)
+		}
+		content.WriteString(cardMarkdown(t, msg))
+	}
+
+	// 拆分后内容完整且顺序不变
+	if content.String() != f.buildMarkdownContent() {
+		t.Error("拆分后的内容与原内容不一致")
+	}
+}
+
+// 测试不会拆开代码块和列表项
+func TestMarkdownBlocks(t *testing.T) {
+	content := "标题\n```go\nfunc main() {\n\n}\n```\n- 列表项\n  续行\n  - 子项\n- 第二项\n1. 有序\n正文\n"
+	expected := []string{
+		"标题\n",
+		"```go\nfunc main() {\n\n}\n```\n",
+		"- 列表项\n  续行\n  - 子项\n",
+		"- 第二项\n",
+		"1. 有序\n",
+		"正文\n",
+	}
+	if blocks := markdownBlocks(content); !reflect.DeepEqual(blocks, expected) {
+		t.Errorf("拆分结果不正确:\n期望 %q\n实际 %q", expected, blocks)
+	}
+}
+
+// 测试按大小合并块，超出预算的块单独成段
+func TestSplitBlocks(t *testing.T) {
+	blocks := []Text{{Content: "aaaa"}, {Content: "bbbb"}, {Content: strings.Repeat("c", 12)}, {Content: "dd"}}
+	chunks := splitBlocks(blocks, 10, func(b Text) int { return len(b.Content) })
+	expected := [][]Text{blocks[:2], blocks[2:3], blocks[3:]}
+	if !reflect.DeepEqual(chunks, expected) {
+		t.Errorf("拆分结果不正确:\n期望 %v\n实际 %v", expected, chunks)
+	}
+}
+
+// 测试截断后再拆分时不会丢失内容
+func TestFormatMsgsSplitTruncated(t *testing.T) {
+	f := &FeishuMsg{Title: "日报", MaxValueRunes: 100}
+	for i := 0; i < 600; i++ {
+		f.MarkdownArray = append(f.MarkdownArray, [2]string{fmt.Sprintf("服务%03d", i), strings.Repeat("正常", 80)})
+	}
+
+	msgs := FormatMsgs(f)
+	if len(msgs) < 2 {
+		t.Fatalf("应该拆分为多张卡片，实际 %d 张", len(msgs))
+	}
+	var content strings.Builder
+	for _, msg := range msgs {
+		content.WriteString(cardMarkdown(t, msg))
+	}
+	if content.String() != f.buildMarkdownContent() {
+		t.Error("拆分后的内容与截断后的内容不一致")
+	}
+	for i := 0; i < 600; i++ {
+		if key := fmt.Sprintf("**服务%03d**", i); !strings.Contains(content.String(), key) {
+			t.Fatalf("内容 %s 丢失", key)
+		}
+	}
+}
+
+// 测试 MarkdownArray 中以代码块开头的值不会被拆开
+func TestFormatMsgsSplitFencedValue(t *testing.T) {
+	var code strings.Builder
+	code.WriteString("```\n")
+	for i := 0; i < 400; i++ {
+		code.WriteString(fmt.Sprintf("line %03d **not bold** <tag>\n", i))
+	}
+	code.WriteString("```")
+
+	f := &FeishuMsg{Title: "日志"}
+	for i := 0; i < 300; i++ {
+		f.MarkdownArray = append(f.MarkdownArray, [2]string{fmt.Sprintf("服务%03d", i), strings.Repeat("正常", 20)})
+		if i == 150 {
+			f.MarkdownArray = append(f.MarkdownArray, [2]string{"日志", code.String()})
+		}
+	}
+
+	msgs := FormatMsgs(f)
+	if len(msgs) < 2 {
+		t.Fatalf("应该拆分为多张卡片，实际 %d 张", len(msgs))
+	}
+	var content strings.Builder
+	for i, msg := range msgs {
+		if size := payloadSize(msg); size > MaxCardSize {
+			t.Errorf("第 %d 张卡片超出大小限制: %d", i+1, size)
+		}
+		md := cardMarkdown(t, msg)
+		if strings.Count(md, "```")%2 != 0 {
+			t.Errorf("第 %d 张卡片中的代码块没有闭合", i+1)
+		}
+		content.WriteString(md)
+	}
+	if content.String() != f.buildMarkdownContent() {
+		t.Error("拆分后的内容与原内容不一致")
+	}
+}
+
+// 测试拆分后保留键，按布局并排展示
+func TestFormatMsgsSplitLayout(t *testing.T) {
+	for _, layout := range []Layout{LayoutFields, LayoutGrid2, LayoutGrid3} {
+		f := &FeishuMsg{Title: "日报", Layout: layout}
+		for i := 0; i < 400; i++ {
+			f.MarkdownArray = append(f.MarkdownArray, [2]string{fmt.Sprintf("服务%03d", i), "正常"})
+		}
+
+		msgs := FormatMsgs(f)
+		if len(msgs) < 2 {
+			t.Fatalf("%s: 应该拆分为多张卡片，实际 %d 张", layout, len(msgs))
+		}
+		for i, msg := range msgs {
+			if size := payloadSize(msg); size > MaxCardSize {
+				t.Errorf("%s: 第 %d 张卡片超出大小限制: %d", layout, i+1, size)
+			}
+			for _, e := range msg.Card.Elements {
+				if e.ElementTag() == "markdown" {
+					t.Errorf("%s: 第 %d 张卡片中的内容项应该并排展示", layout, i+1)
+					break
+				}
+			}
+		}
+	}
+}
+
+// 测试键前缀后的代码块和同一行闭合的代码标记
+func TestMarkdownBlocksEntryFence(t *testing.T) {
+	content := "**日志**：```\na\n```\n**代码**：```x``` 说明\n正文\n"
+	expected := []string{
+		"**日志**：```\na\n```\n",
+		"**代码**：```x``` 说明\n",
+		"正文\n",
+	}
+	if blocks := markdownBlocks(content); !reflect.DeepEqual(blocks, expected) {
+		t.Errorf("拆分结果不正确:\n期望 %q\n实际 %q", expected, blocks)
+	}
+}
+
+// 测试按顺序发送拆分后的卡片
+func TestClientSendSplit(t *testing.T) {
+	var mu sync.Mutex
+	var titles []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg struct {
+			Card struct {
+				Header Header `json:"header"`
+			} `json:"card"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		mu.Lock()
+		titles = append(titles, msg.Card.Header.Title.Content)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	f := &FeishuMsg{Title: "日报"}
+	for i := 0; i < 100; i++ {
+		f.MarkdownItems = append(f.MarkdownItems, Text{Content: strings.Repeat("内容", 200)})
+	}
+
+	results, err := NewClient(WithHook(server.URL)).SendSplit(context.Background(), f)
+	if err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+	if len(results) != len(titles) || len(titles) < 2 {
+		t.Fatalf("发送数量不正确: %d 个结果, %d 个请求", len(results), len(titles))
+	}
+	for i, title := range titles {
+		if expected := fmt.Sprintf("日报 (%d/%d)", i+1, len(titles)); title != expected {
+			t.Errorf("发送顺序不正确: 期望 %s, 实际 %s", expected, title)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+ * @Description: 表格组件
+ * 文档 https://open.feishu.cn/document/feishu-cards/card-json-v2-components/content-components/table
+ * 表格由列定义（columns）和行数据（rows）组成，rows 中每行的键对应列的 name，
+ * 列的数据类型决定了单元格的值：
+ * text、lark_md、markdown：字符串
+ * number：数字，可通过 format 设置精度、千分位和前缀符号
+ * options：选项列表 []TableOption
+ * persons：用户 ID 列表 []string
+ * date：毫秒时间戳，可通过 date_format 设置显示格式
+ */
+
+// 表格列的数据类型
+const (
+	TableDataText     = "text"
+	TableDataLarkMd   = "lark_md"
+	TableDataMarkdown = "markdown"
+	TableDataNumber   = "number"
+	TableDataOptions  = "options"
+	TableDataPersons  = "persons"
+	TableDataDate     = "date"
+)
+
+// TableNumberFormat 数字列的格式
+type TableNumberFormat struct {
+	Symbol    string `json:"symbol,omitempty"`    // 前缀符号，如 ¥
+	Precision *int   `json:"precision,omitempty"` // 小数位数
+	Separator bool   `json:"separator,omitempty"` // 是否使用千分位分隔符
+
+	Unknown map[string]json.RawMessage `json:"-"` // 解码时保留的未知字段，序列化时原样输出
+}
+
+// TableOption 选项列的单元格值
+type TableOption struct {
+	Text  string `json:"text"`
+	Color string `json:"color,omitempty"` // neutral、blue、turquoise、lime、orange、violet、indigo、wathet、green、yellow、red、purple、carmine
+}
+
+// NewTableFromRows 使用字符串表格构建表格组件，第一行为表头，所有列都是文本类型
+func NewTableFromRows(rows [][]string) (TableElement, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return TableElement{}, fmt.Errorf("table has no header")
+	}
+
+	header := rows[0]
+	columns := make([]TableColumn, 0, len(header))
+	for i, name := range header {
+		columns = append(columns, TableColumn{
+			Name:        "col" + strconv.Itoa(i),
+			DisplayName: name,
+			DataType:    TableDataText,
+		})
+	}
+
+	data := make([]map[string]any, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		if len(row) > len(header) {
+			return TableElement{}, fmt.Errorf("table row %d has %d cells, header has %d", i+1, len(row), len(header))
+		}
+		cells := make(map[string]any, len(header))
+		for j := range header {
+			cell := ""
+			if j < len(row) {
+				cell = row[j]
+			}
+			cells[columns[j].Name] = cell
+		}
+		data = append(data, cells)
+	}
+
+	return TableElement{Columns: columns, Rows: data}, nil
+}
+
+// NewTableFromStructs 使用结构体切片构建表格组件，每个导出字段为一列
+// 通过 table 标签设置列：`table:"显示名称,type=number,width=auto,align=right,precision=2,symbol=¥,date_format=YYYY-MM-DD"`
+// 显示名称为空时使用字段名，`table:"-"` 表示忽略该字段
+// 未指定 type 时按字段类型推断：数字为 number，time.Time 为 date，其余为 text
+func NewTableFromStructs(items any) (TableElement, error) {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return TableElement{}, fmt.Errorf("table items must be a slice, got %T", items)
+	}
+	elemType := v.Type().Elem()
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return TableElement{}, fmt.Errorf("table items must be structs, got %s", elemType)
+	}
+
+	var columns []TableColumn
+	var fields []int
+	for i := 0; i < elemType.NumField(); i++ {
+		field := elemType.Field(i)
+		tag := field.Tag.Get("table")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		column, err := parseTableColumn(field, tag)
+		if err != nil {
+			return TableElement{}, err
+		}
+		columns = append(columns, column)
+		fields = append(fields, i)
+	}
+	if len(columns) == 0 {
+		return TableElement{}, fmt.Errorf("table struct %s has no exported fields", elemType)
+	}
+
+	rows := make([]map[string]any, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		item := v.Index(i)
+		if item.Kind() == reflect.Pointer {
+			if item.IsNil() {
+				continue
+			}
+			item = item.Elem()
+		}
+		row := make(map[string]any, len(columns))
+		for j, column := range columns {
+			if cell, ok := tableCell(item.Field(fields[j]), column.DataType); ok {
+				row[column.Name] = cell
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return TableElement{Columns: columns, Rows: rows}, nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// parseTableColumn 按字段类型和 table 标签生成列定义
+func parseTableColumn(field reflect.StructField, tag string) (TableColumn, error) {
+	column := TableColumn{Name: field.Name, DisplayName: field.Name}
+	parts := strings.Split(tag, ",")
+	if parts[0] != "" {
+		column.DisplayName = parts[0]
+	}
+
+	for _, part := range parts[1:] {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return column, fmt.Errorf("invalid table tag %q on field %s", part, field.Name)
+		}
+		switch key {
+		case "type":
+			column.DataType = value
+		case "width":
+			column.Width = value
+		case "align":
+			column.HorizontalAlign = value
+		case "date_format":
+			column.DateFormat = value
+		case "symbol":
+			if column.Format == nil {
+				column.Format = &TableNumberFormat{}
+			}
+			column.Format.Symbol = value
+		case "precision":
+			precision, err := strconv.Atoi(value)
+			if err != nil {
+				return column, fmt.Errorf("invalid table precision %q on field %s", value, field.Name)
+			}
+			if column.Format == nil {
+				column.Format = &TableNumberFormat{}
+			}
+			column.Format.Precision = &precision
+		default:
+			return column, fmt.Errorf("unknown table tag key %q on field %s", key, field.Name)
+		}
+	}
+
+	if column.DataType == "" {
+		t := field.Type
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		switch {
+		case t == timeType:
+			column.DataType = TableDataDate
+		case t.Kind() >= reflect.Int && t.Kind() <= reflect.Float64:
+			column.DataType = TableDataNumber
+		default:
+			column.DataType = TableDataText
+		}
+	}
+	return column, nil
+}
+
+// tableCell 将字段值转换为单元格的值，nil 指针返回 false
+func tableCell(v reflect.Value, dataType string) (any, bool) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+
+	switch dataType {
+	case TableDataNumber:
+		if v.Kind() >= reflect.Int && v.Kind() <= reflect.Float64 {
+			return v.Interface(), true
+		}
+	case TableDataDate:
+		if t, ok := v.Interface().(time.Time); ok {
+			return t.UnixMilli(), true
+		}
+	case TableDataOptions:
+		switch value := v.Interface().(type) {
+		case []TableOption:
+			return value, true
+		case []string:
+			options := make([]TableOption, 0, len(value))
+			for _, text := range value {
+				options = append(options, TableOption{Text: text})
+			}
+			return options, true
+		case string:
+			return []TableOption{{Text: value}}, true
+		}
+	case TableDataPersons:
+		switch value := v.Interface().(type) {
+		case []string:
+			return value, true
+		case string:
+			return []string{value}, true
+		}
+	}
+	return fmt.Sprint(v.Interface()), true
+}
+
+// createTableElements 将表格作为组件，未设置的每页行数使用 10 行
+func createTableElements(tables []TableElement) []CardElement {
+	elements := make([]CardElement, 0, len(tables))
+	for _, table := range tables {
+		if table.PageSize == 0 {
+			table.PageSize = 10
+		}
+		elements = append(elements, table)
+	}
+	return elements
+}
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 测试使用字符串表格构建表格
+func TestNewTableFromRows(t *testing.T) {
+	table, err := NewTableFromRows([][]string{
+		{"服务", "状态"},
+		{"api", "正常"},
+		{"worker"},
+	})
+	if err != nil {
+		t.Fatalf("构建表格失败: %v", err)
+	}
+	if len(table.Columns) != 2 || table.Columns[1].Name != "col1" || table.Columns[1].DisplayName != "状态" || table.Columns[1].DataType != TableDataText {
+		t.Errorf("列定义不正确: %+v", table.Columns)
+	}
+	if len(table.Rows) != 2 || table.Rows[0]["col1"] != "正常" || table.Rows[1]["col1"] != "" {
+		t.Errorf("行数据不正确: %+v", table.Rows)
+	}
+
+	if _, err := NewTableFromRows(nil); err == nil {
+		t.Error("没有表头应该返回错误")
+	}
+	if _, err := NewTableFromRows([][]string{{"a"}, {"1", "2"}}); err == nil {
+		t.Error("单元格多于表头应该返回错误")
+	}
+}
+
+type tableReport struct {
+	Service string    `table:"服务"`
+	QPS     float64   `table:"QPS,precision=2,align=right"`
+	Cost    int       `table:"费用,symbol=¥"`
+	Tags    []string  `table:"标签,type=options"`
+	Owner   string    `table:"负责人,type=persons"`
+	Updated time.Time `table:"更新时间,date_format=YYYY-MM-DD"`
+	Secret  string    `table:"-"`
+	Note    *string
+	private string
+}
+
+// 测试使用结构体切片构建表格
+func TestNewTableFromStructs(t *testing.T) {
+	updated := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	table, err := NewTableFromStructs([]*tableReport{
+		{Service: "api", QPS: 12.5, Cost: 100, Tags: []string{"prod", "core"}, Owner: "ou_1", Updated: updated, Secret: "x"},
+		nil,
+	})
+	if err != nil {
+		t.Fatalf("构建表格失败: %v", err)
+	}
+
+	expected := []struct{ name, display, dataType string }{
+		{"Service", "服务", TableDataText},
+		{"QPS", "QPS", TableDataNumber},
+		{"Cost", "费用", TableDataNumber},
+		{"Tags", "标签", TableDataOptions},
+		{"Owner", "负责人", TableDataPersons},
+		{"Updated", "更新时间", TableDataDate},
+		{"Note", "Note", TableDataText},
+	}
+	if len(table.Columns) != len(expected) {
+		t.Fatalf("列数量不正确: %+v", table.Columns)
+	}
+	for i, e := range expected {
+		c := table.Columns[i]
+		if c.Name != e.name || c.DisplayName != e.display || c.DataType != e.dataType {
+			t.Errorf("第 %d 列不正确: %+v", i, c)
+		}
+	}
+	if f := table.Columns[1].Format; f == nil || *f.Precision != 2 || table.Columns[1].HorizontalAlign != "right" {
+		t.Errorf("数字格式不正确: %+v", table.Columns[1])
+	}
+	if table.Columns[2].Format.Symbol != "¥" || table.Columns[5].DateFormat != "YYYY-MM-DD" {
+		t.Errorf("列格式不正确: %+v", table.Columns)
+	}
+
+	if len(table.Rows) != 1 {
+		t.Fatalf("nil 元素应该被忽略: %+v", table.Rows)
+	}
+	row := table.Rows[0]
+	if row["QPS"] != 12.5 || row["Updated"] != updated.UnixMilli() {
+		t.Errorf("单元格不正确: %+v", row)
+	}
+	if _, ok := row["Note"]; ok {
+		t.Error("nil 指针字段不应该输出")
+	}
+
+	data, _ := json.Marshal(table)
+	for _, s := range []string{
+		`"tag":"table"`,
+		`"Tags":[{"text":"prod"},{"text":"core"}]`,
+		`"Owner":["ou_1"]`,
+		`"format":{"precision":2}`,
+	} {
+		if !strings.Contains(string(data), s) {
+			t.Errorf("序列化结果缺少 %s: %s", s, data)
+		}
+	}
+
+	if _, err := NewTableFromStructs([]int{1}); err == nil {
+		t.Error("非结构体切片应该返回错误")
+	}
+	if _, err := NewTableFromStructs([]struct {
+		A int `table:"a,bad"`
+	}{}); err == nil {
+		t.Error("无效的标签应该返回错误")
+	}
+}
+
+// 测试 FeishuMsg.Tables 的渲染和校验
+func TestFeishuMsgTables(t *testing.T) {
+	table, _ := NewTableFromRows([][]string{{"服务"}, {"api"}})
+	f := &FeishuMsg{
+		Title:         "日报",
+		MarkdownArray: [][2]string{{"日期", "今天"}},
+		Tables:        []TableElement{table},
+		Images:        []string{"img_v2_xxx"},
+		Note:          "备注",
+	}
+	for _, schema := range []CardSchema{CardSchemaV1, CardSchemaV2} {
+		f.Schema = schema
+		msg := FormatMsg(f)
+		elements := msg.Card.Elements
+		if schema == CardSchemaV2 {
+			elements = msg.Card.Body.Elements
+		}
+		tab, ok := elements[1].(TableElement)
+		if !ok || elements[2].ElementTag() != "img" {
+			t.Fatalf("表格应该在内容之后、图片之前: %+v", elements)
+		}
+		if tab.PageSize != 10 {
+			t.Errorf("默认每页行数不正确: %d", tab.PageSize)
+		}
+		if err := Validate(msg); err != nil {
+			t.Errorf("校验失败: %v", err)
+		}
+	}
+
+	table.PageSize = 20
+	table.Columns = append(table.Columns, TableColumn{Name: "col0"})
+	f.Tables = []TableElement{table}
+	paths := validationPaths(t, Validate(FormatMsg(f)))
+	for _, p := range []string{
+		"card.body.elements[1].page_size",
+		"card.body.elements[1].columns[1].name",
+		"card.body.elements[1].columns[1].data_type",
+	} {
+		if _, ok := paths[p]; !ok {
+			t.Errorf("缺少校验错误 %s: %v", p, paths)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package bot
+
+import (
+	"context"
+	"strings"
+)
+
+/**
+ * @Description: 文本消息
+ * 文档 https://open.feishu.cn/document/client-docs/bot-v3/add-custom-bot#756b882f
+ * 文本消息比卡片更轻量，并且支持 @ 用户的强提醒：
+ * @ 单个用户 <at user_id="ou_xxx">名字</at>
+ * @ 所有人   <at user_id="all">所有人</at>
+ */
+
+var (
+	// 转义 @ 标签中的名字
+	atNameEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	// 转义 @ 标签中的属性值
+	atAttrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+// AtUser 构建 @ 指定用户的标签，userID 支持 open_id、user_id
+func AtUser(userID, name string) string {
+	return `<at user_id="` + atAttrEscaper.Replace(userID) + `">` + atNameEscaper.Replace(name) + `</at>`
+}
+
+// AtAll 构建 @ 所有人的标签
+func AtAll() string {
+	return AtUser("all", "所有人")
+}
+
+// NewTextMsg 构建一条文本消息
+func NewTextMsg(text string) *Msg {
+	return &Msg{
+		MsgType: MsgTypeText,
+		Content: &Content{Text: text},
+	}
+}
+
+// TextBuilder 文本消息构建器
+type TextBuilder struct {
+	sb strings.Builder
+}
+
+// NewText 创建文本消息构建器
+func NewText() *TextBuilder {
+	return &TextBuilder{}
+}
+
+// Text 追加文本
+func (b *TextBuilder) Text(text string) *TextBuilder {
+	b.sb.WriteString(text)
+	return b
+}
+
+// Line 追加一行文本
+func (b *TextBuilder) Line(text string) *TextBuilder {
+	b.sb.WriteString(text)
+	b.sb.WriteString("\n")
+	return b
+}
+
+// At 追加 @ 指定用户
+func (b *TextBuilder) At(userID, name string) *TextBuilder {
+	b.sb.WriteString(AtUser(userID, name))
+	return b
+}
+
+// AtAll 追加 @ 所有人
+func (b *TextBuilder) AtAll() *TextBuilder {
+	b.sb.WriteString(AtAll())
+	return b
+}
+
+// String 返回文本内容
+func (b *TextBuilder) String() string {
+	return b.sb.String()
+}
+
+// Build 构建文本消息
+func (b *TextBuilder) Build() *Msg {
+	return NewTextMsg(b.String())
+}
+
+// SendText 发送一条文本消息
+func (c *Client) SendText(ctx context.Context, text string) (*SendResult, error) {
+	return c.SendMsg(ctx, NewTextMsg(text))
+}
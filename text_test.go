@@ -0,0 +1,77 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// 测试 @ 标签转义
+func TestAtUser(t *testing.T) {
+	cases := []struct {
+		userID, name, expected string
+	}{
+		{"ou_123", "张三", `<at user_id="ou_123">张三</at>`},
+		{`ou_"x"`, "<b>李四</b>", `<at user_id="ou_&quot;x&quot;">&lt;b&gt;李四&lt;/b&gt;</at>`},
+	}
+	for _, c := range cases {
+		if got := AtUser(c.userID, c.name); got != c.expected {
+			t.Errorf("期望 %s，实际 %s", c.expected, got)
+		}
+	}
+	if got := AtAll(); got != `<at user_id="all">所有人</at>` {
+		t.Errorf("@所有人 不正确: %s", got)
+	}
+}
+
+// 测试文本消息序列化
+func TestTextMsgMarshal(t *testing.T) {
+	msg := NewText().Line("服务告警").Text("负责人：").At("ou_123", "张三").Text(" ").AtAll().Build()
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("序列化失败: %v", err)
+	}
+	var got struct {
+		MsgType string          `json:"msg_type"`
+		Card    json.RawMessage `json:"card"`
+		Content struct {
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("反序列化失败: %v", err)
+	}
+
+	expected := "服务告警\n负责人：<at user_id=\"ou_123\">张三</at> <at user_id=\"all\">所有人</at>"
+	if got.MsgType != MsgTypeText || got.Card != nil || got.Content.Text != expected {
+		t.Errorf("序列化结果不正确: %s", data)
+	}
+}
+
+// 测试文本消息通过 Client 发送并签名
+func TestClientSendText(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithHook(server.URL), WithSecret("demo_secret"))
+	if _, err := client.SendText(context.Background(), "你好"); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+
+	if got["msg_type"] != MsgTypeText || got["card"] != nil || got["sign"] == nil {
+		t.Errorf("请求体不正确: %v", got)
+	}
+	content, _ := got["content"].(map[string]any)
+	if content["text"] != "你好" {
+		t.Errorf("文本内容不正确: %v", got["content"])
+	}
+}
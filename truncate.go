@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+/**
+ * @Description: 截断超长内容
+ * 设置 FeishuMsg.MaxValueRunes、MaxBodyRunes 后，超出的内容会被截断（按字符数而不是字节数计算），
+ * 截断时补齐未闭合的 **、代码块、行内代码和 <font> 标签，保证 Markdown 正常渲染，
+ * 并追加 "…(truncated)"，设置了 Link 时追加查看完整内容的链接
+ */
+
+// TruncatedMark 截断后追加的标记
+const TruncatedMark = "…(truncated)"
+
+// partialTagPattern 截断处未写完的标签，如 <font color='red、</fo、<
+// 标签很短，只检查末尾一段，不把正文中的 "a < b" 当作标签
+var partialTagPattern = regexp.MustCompile(`<(/?[A-Za-z][^<>\n]{0,63}|/)?$`)
+
+// truncateMarkdown 将内容截断为最多 max 个字符（不含补齐的标签和标记）
+func truncateMarkdown(s string, max int, link string) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+
+	runes := []rune(s)
+	prefix := safeCut(string(runes[:max]), runes[max])
+
+	closers := unclosedMarkdown(prefix)
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	for i := len(closers) - 1; i >= 0; i-- {
+		closer := closers[i]
+		if i == len(closers)-1 && strings.HasSuffix(prefix, "\n") {
+			closer = strings.TrimPrefix(closer, "\n")
+		}
+		sb.WriteString(closer)
+	}
+	sb.WriteString(TruncatedMark)
+	if link != "" {
+		sb.WriteString(fmt.Sprintf(" [查看完整内容](%s)", link))
+	}
+	if strings.HasSuffix(s, "\n") {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// safeCut 调整截断位置，避免截断在标签、** 或代码块标记中间，next 为截断处的下一个字符
+func safeCut(prefix string, next rune) string {
+	// 未写完的标签，如 <font color='red
+	if loc := partialTagPattern.FindStringIndex(prefix); loc != nil {
+		prefix = prefix[:loc[0]]
+	}
+	// 被截断的 **
+	if next == '*' && strings.HasSuffix(prefix, "*") && !strings.HasSuffix(prefix, "**") {
+		prefix = prefix[:len(prefix)-1]
+	}
+	// 被截断的代码块标记
+	lastLine := prefix[strings.LastIndex(prefix, "\n")+1:]
+	if trimmed := strings.TrimSpace(lastLine); trimmed != "" && strings.Trim(trimmed, "`~") == "" && len(trimmed) < 3 {
+		prefix = prefix[:len(prefix)-len(lastLine)]
+	}
+	return prefix
+}
+
+// unclosedMarkdown 返回未闭合标记对应的闭合标签，按打开顺序排列
+// 代码块（``` 或 ~~~）和行内代码中的内容不参与匹配
+func unclosedMarkdown(s string) []string {
+	var stack []string
+	fence, inline := "", ""
+	for i := 0; i < len(s); {
+		lineStart := i == 0 || s[i-1] == '\n'
+		rest := s[i:]
+		line := rest
+		if j := strings.IndexByte(rest, '\n'); j >= 0 {
+			line = rest[:j+1]
+		}
+		switch {
+		case lineStart && inline == "" && fenceMarker(line) != "" && (fence == "" || fenceMarker(line) == fence):
+			if fence == "" {
+				fence = fenceMarker(line)
+				stack = append(stack, "\n"+fence+"\n")
+			} else {
+				fence = ""
+				stack = stack[:len(stack)-1]
+			}
+			// 跳过整行，语言标识中的字符不参与匹配
+			i += len(line)
+			continue
+		case fence != "":
+		case rest[0] == '`':
+			// 行内代码：到相同长度的反引号为止
+			run := rest[:len(rest)-len(strings.TrimLeft(rest, "`"))]
+			if inline == "" {
+				inline = run
+				stack = append(stack, run)
+			} else if run == inline {
+				inline = ""
+				stack = stack[:len(stack)-1]
+			}
+			i += len(run)
+			continue
+		case inline != "":
+		case strings.HasPrefix(rest, "**"):
+			if n := len(stack); n > 0 && stack[n-1] == "**" {
+				stack = stack[:n-1]
+			} else {
+				stack = append(stack, "**")
+			}
+			i += 2
+			continue
+		case strings.HasPrefix(rest, "<font"):
+			stack = append(stack, "</font>")
+		case strings.HasPrefix(rest, "</font>"):
+			for j := len(stack) - 1; j >= 0; j-- {
+				if stack[j] == "</font>" {
+					stack = append(stack[:j], stack[j+1:]...)
+					break
+				}
+			}
+			i += len("</font>")
+			continue
+		}
+		i++
+	}
+	return stack
+}
@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// 测试截断并补齐未闭合的标记
+func TestTruncateMarkdown(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		max      int
+		expected string
+	}{
+		{"未超出", "你好世界", 4, "你好世界"},
+		{"按字符计数", "你好世界", 2, "你好" + TruncatedMark},
+		{"补齐加粗", "**重要通知**", 4, "**重要**" + TruncatedMark},
+		{"补齐字体标签", "<font color='red'>错误信息</font>", 20, "<font color='red'>错误</font>" + TruncatedMark},
+		{"不截断在标签中间", "正常<font color='red'>错误</font>", 6, "正常" + TruncatedMark},
+		{"不截断在 ** 中间", "正常**加粗**", 3, "正常" + TruncatedMark},
+		{"嵌套标记", "<font color='red'>**错误信息**</font>", 22, "<font color='red'>**错误**</font>" + TruncatedMark},
+		{"补齐代码块", "日志：\n```\nline1\nline2\n```", 14, "日志：\n```\nline1\n```\n" + TruncatedMark},
+		{"代码块中的 ** 不处理", "```go\na ** b\nc\n```", 12, "```go\na ** b\n```\n" + TruncatedMark},
+		{"补齐 ~~~ 代码块", "~~~\nabcdefgh\n~~~", 8, "~~~\nabcd\n~~~\n" + TruncatedMark},
+		{"~~~ 代码块中的 ``` 不结束代码块", "~~~\n```\nabcdef\n~~~", 12, "~~~\n```\nabcd\n~~~\n" + TruncatedMark},
+		{"行内代码中的 ** 不处理", "`a**b` 后面的内容", 9, "`a**b` 后面" + TruncatedMark},
+		{"补齐行内代码", "说明 `a**bcdef`", 8, "说明 `a**b`" + TruncatedMark},
+		{"正文中的 <", "latency < 100ms is fine, p99 < 200ms", 20, "latency < 100ms is f" + TruncatedMark},
+		{"截断在结束标签中间", "<font color='red'>错误</font>", 22, "<font color='red'>错误</font>" + TruncatedMark},
+	}
+	for _, c := range cases {
+		if got := truncateMarkdown(c.input, c.max, ""); got != c.expected {
+			t.Errorf("%s: 期望 %q, 实际 %q", c.name, c.expected, got)
+		}
+	}
+}
+
+// 测试截断后附带完整内容链接
+func TestTruncateMarkdownLink(t *testing.T) {
+	got := truncateMarkdown("很长的内容\n", 2, "https://example.com")
+	expected := "很长" + TruncatedMark + " [查看完整内容](https://example.com)\n"
+	if got != expected {
+		t.Errorf("期望 %q, 实际 %q", expected, got)
+	}
+}
+
+// 测试 FeishuMsg 的截断配置
+func TestFeishuMsgTruncate(t *testing.T) {
+	long := strings.Repeat("错误", 100)
+	f := &FeishuMsg{
+		Title:         "告警",
+		MarkdownArray: [][2]string{{"详情", long}, {"状态", "失败"}},
+		MaxValueRunes: 10,
+	}
+	content := f.buildMarkdownContent()
+	expected := "**详情**：" + strings.Repeat("错误", 5) + TruncatedMark + "\n**状态**：失败\n"
+	if content != expected {
+		t.Errorf("每项内容截断不正确:\n期望 %q\n实际 %q", expected, content)
+	}
+
+	f.MaxValueRunes = 0
+	f.MaxBodyRunes = 50
+	f.Link = "https://example.com"
+	content = f.buildMarkdownContent()
+	if !strings.Contains(content, TruncatedMark+" [查看完整内容](https://example.com)") {
+		t.Errorf("全部内容截断后应该附带链接: %q", content)
+	}
+	if n := utf8.RuneCountInString(strings.SplitN(content, TruncatedMark, 2)[0]); n > 50 {
+		t.Errorf("截断后的字符数 %d 超出限制", n)
+	}
+	if strings.Count(content, "**")%2 != 0 {
+		t.Errorf("加粗标记没有闭合: %q", content)
+	}
+}
@@ -0,0 +1,528 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+/**
+ * @Description: 发送前校验消息卡片
+ * 飞书会拒绝超出限制的卡片，但只返回笼统的错误信息，发送前校验可以定位到具体的字段：
+ * 1. 请求体不超过 30 KB，组件总数不超过 200 个
+ * 2. 必须有标题和至少一个组件，组件必须有 tag，标题颜色必须是 FeishuColor 中的值
+ * 3. 列的权重为 1-5，每组按钮不超过 20 个
+ * 4. 图片 key 以 img_ 开头，链接必须是 http(s) 链接
+ */
+
+const (
+	MaxCardSize      = 30 * 1024 // 请求体最大字节数
+	MaxCardElements  = 200       // 组件最大数量（包括嵌套的组件）
+	MaxButtons       = 20        // 每组按钮最大数量
+	MinColumnWeight  = 1         // 列的最小权重
+	MaxColumnWeight  = 5         // 列的最大权重
+	MaxTablePageSize = 10        // 表格每页最大行数
+	MaxInputLength   = 1000      // 输入框最大字符数
+)
+
+var imageKeyPattern = regexp.MustCompile(`^img_[A-Za-z0-9_-]+$`)
+
+// ValidationError 单个校验错误，Path 为出错字段的 JSON 路径
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors 校验错误列表
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return "invalid message: " + strings.Join(msgs, "; ")
+}
+
+// Validate 按飞书的限制校验消息，返回 ValidationErrors
+func Validate(msg *Msg) error {
+	v := &validator{}
+	if err := msg.ValidateContent(); err != nil {
+		v.add("", "%s", strings.TrimPrefix(err.Error(), "invalid message: "))
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		v.add("", "failed to marshal message: %v", err)
+	} else if len(data) > MaxCardSize {
+		v.add("", "payload size %d bytes exceeds %d bytes", len(data), MaxCardSize)
+	}
+
+	if msg.MsgType == MsgTypeInteractive {
+		v.card("card", &msg.Card)
+	}
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+// WithValidation 发送前使用 Validate 校验消息，校验失败时不发送
+func WithValidation() ClientOption {
+	return func(c *Client) {
+		c.validate = true
+	}
+}
+
+// validator 递归校验卡片并收集错误
+type validator struct {
+	errs     ValidationErrors
+	elements int
+
+	names   map[string]string // 表单和表单组件的 name 及其路径，每棵组件树内唯一
+	inForm  bool              // 是否在表单容器中
+	submits int               // 当前表单中的提交按钮数量
+}
+
+func (v *validator) add(path, format string, args ...any) {
+	v.errs = append(v.errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) card(path string, c *Card) {
+	if !c.Header.isZero() {
+		v.header(path+".header", &c.Header)
+	} else if !c.hasI18nHeader() {
+		v.add(path+".header.title", "header title is required")
+	}
+	if c.CardLink != nil {
+		v.cardLink(path+".card_link", c.CardLink)
+	}
+
+	if c.Schema == string(CardSchemaV2) {
+		elements := c.Elements
+		elementsPath := path + ".body.elements"
+		if c.Body != nil {
+			elements = c.Body.Elements
+		}
+		v.nonEmpty(elementsPath, elements)
+		v.tree(elementsPath, elements)
+	} else {
+		if !c.hasI18nElements() {
+			v.nonEmpty(path+".elements", c.Elements)
+		}
+		v.tree(path+".elements", c.Elements)
+		if i := c.I18nElements; i != nil {
+			langs := []string{LangZhCn, LangEnUs, LangJaJp}
+			for j, e := range []*I18nElement{i.ZhCn, i.EnUs, i.JaJp} {
+				if e == nil {
+					continue
+				}
+				p := path + ".i18n_elements." + langs[j]
+				if e.Header != nil {
+					v.header(p+".header", e.Header)
+				}
+				v.nonEmpty(p+".elements", e.Elements)
+				v.tree(p+".elements", e.Elements)
+			}
+		}
+	}
+
+	if v.elements > MaxCardElements {
+		v.add(path, "card has %d elements, exceeds %d", v.elements, MaxCardElements)
+	}
+}
+
+// hasI18nHeader 是否设置了多语言标题，设置后可以不设置 header
+func (c *Card) hasI18nHeader() bool {
+	if _, ok := c.Unknown["i18n_header"]; ok {
+		return true
+	}
+	if i := c.I18nElements; i != nil {
+		for _, e := range []*I18nElement{i.ZhCn, i.EnUs, i.JaJp} {
+			if e != nil && e.Header != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasI18nElements 是否设置了多语言内容，设置后可以不设置 elements
+func (c *Card) hasI18nElements() bool {
+	i := c.I18nElements
+	return i != nil && (i.ZhCn != nil || i.EnUs != nil || i.JaJp != nil || len(i.Unknown) > 0)
+}
+
+// nonEmpty 卡片内容至少包含一个组件
+func (v *validator) nonEmpty(path string, elements []CardElement) {
+	if len(elements) == 0 {
+		v.add(path, "card has no elements")
+	}
+}
+
+func (v *validator) header(path string, h *Header) {
+	if strings.TrimSpace(h.Title.Content) == "" {
+		v.add(path+".title.content", "header title is empty")
+	}
+	if h.Title.Tag == "" {
+		v.add(path+".title.tag", "tag is required")
+	}
+	if h.Template != "" && !isValidColor(FeishuColor(h.Template)) {
+		v.add(path+".template", "invalid header color %q", h.Template)
+	}
+}
+
+func (v *validator) cardLink(path string, l *CardLink) {
+	v.url(path+".url", l.Url)
+	v.optionalURL(path+".android_url", l.AndroidUrl)
+	v.optionalURL(path+".ios_url", l.IosUrl)
+	v.optionalURL(path+".pc_url", l.PCUrl)
+}
+
+func (v *validator) url(path, s string) {
+	if !isValidURL(s) {
+		v.add(path, "invalid url %q, must be http or https", s)
+	}
+}
+
+func (v *validator) optionalURL(path, s string) {
+	if s != "" {
+		v.url(path, s)
+	}
+}
+
+func (v *validator) imageKey(path, key string) {
+	if !imageKeyPattern.MatchString(key) {
+		v.add(path, "invalid image key %q", key)
+	}
+}
+
+func (v *validator) text(path string, t *Text) {
+	if t != nil && t.Tag == "" {
+		v.add(path+".tag", "tag is required")
+	}
+}
+
+// tree 校验一棵组件树，每种语言的组件树独立，name 只需在树内唯一
+func (v *validator) tree(path string, elements []CardElement) {
+	v.names = make(map[string]string)
+	v.elementList(path, elements)
+}
+
+func (v *validator) elementList(path string, elements []CardElement) {
+	for i, e := range elements {
+		v.element(fmt.Sprintf("%s[%d]", path, i), e)
+	}
+}
+
+func (v *validator) element(path string, e CardElement) {
+	if e == nil {
+		v.add(path, "element is nil")
+		return
+	}
+	v.elements++
+	if e.ElementTag() == "" {
+		v.add(path+".tag", "tag is required")
+	}
+
+	switch e := e.(type) {
+	case Element:
+		v.legacyElement(path, &e)
+	case *Element:
+		v.legacyElement(path, e)
+	case MarkdownElement, HrElement, RawElement, Text:
+	case TableElement:
+		v.table(path, e)
+	case ChartElement:
+		v.chart(path, e)
+	case CollapsiblePanelElement:
+		if e.Header.Title == nil {
+			v.add(path+".header.title", "title is required")
+		}
+		v.text(path+".header.title", e.Header.Title)
+		v.elementList(path+".elements", e.Elements)
+	case DivElement:
+		v.text(path+".text", e.Text)
+		for i, f := range e.Fields {
+			v.text(fmt.Sprintf("%s.fields[%d].text", path, i), f.Text)
+		}
+		if e.Extra != nil {
+			v.element(path+".extra", e.Extra)
+		}
+	case ImageElement:
+		v.imageKey(path+".img_key", e.ImgKey)
+	case ColumnSetElement:
+		v.columns(path+".columns", e.Columns)
+	case NoteElement:
+		v.elementList(path+".elements", e.Elements)
+	case ActionElement:
+		v.actions(path+".actions", e.Actions)
+	case ButtonElement:
+		v.button(path, e)
+	case FormElement:
+		v.form(path, e)
+	case InputElement:
+		v.field(path, e.Name)
+		v.text(path+".placeholder", e.Placeholder)
+		v.text(path+".label", e.Label)
+		if e.MaxLength < 0 || e.MaxLength > MaxInputLength {
+			v.add(path+".max_length", "max length %d out of range [1, %d]", e.MaxLength, MaxInputLength)
+		}
+	case SelectStaticElement:
+		v.field(path, e.Name)
+		v.text(path+".placeholder", e.Placeholder)
+		v.options(path+".options", e.Options)
+	case MultiSelectStaticElement:
+		v.field(path, e.Name)
+		v.text(path+".placeholder", e.Placeholder)
+		v.options(path+".options", e.Options)
+	case DatePickerElement:
+		v.field(path, e.Name)
+		v.text(path+".placeholder", e.Placeholder)
+	case TimePickerElement:
+		v.field(path, e.Name)
+		v.text(path+".placeholder", e.Placeholder)
+	case CheckerElement:
+		v.field(path, e.Name)
+		v.text(path+".text", e.Text)
+	default:
+		if p, ok := derefElement(e); ok {
+			v.elements--
+			v.element(path, p)
+		}
+	}
+}
+
+// derefElement 将组件指针转换为值，便于统一校验
+func derefElement(e CardElement) (CardElement, bool) {
+	switch e := e.(type) {
+	case *MarkdownElement:
+		return *e, true
+	case *DivElement:
+		return *e, true
+	case *ImageElement:
+		return *e, true
+	case *ColumnSetElement:
+		return *e, true
+	case *HrElement:
+		return *e, true
+	case *NoteElement:
+		return *e, true
+	case *ActionElement:
+		return *e, true
+	case *ButtonElement:
+		return *e, true
+	case *TableElement:
+		return *e, true
+	case *FormElement:
+		return *e, true
+	case *ChartElement:
+		return *e, true
+	case *CollapsiblePanelElement:
+		return *e, true
+	case *InputElement:
+		return *e, true
+	case *SelectStaticElement:
+		return *e, true
+	case *MultiSelectStaticElement:
+		return *e, true
+	case *DatePickerElement:
+		return *e, true
+	case *TimePickerElement:
+		return *e, true
+	case *CheckerElement:
+		return *e, true
+	}
+	return nil, false
+}
+
+func (v *validator) legacyElement(path string, e *Element) {
+	switch e.Tag {
+	case "img":
+		v.imageKey(path+".img_key", e.ImgKey)
+	case "action":
+		v.actions(path+".actions", e.Actions)
+	}
+	v.text(path+".text", e.Text)
+	v.columns(path+".columns", e.Columns)
+	for i, child := range e.Elements {
+		v.element(fmt.Sprintf("%s.elements[%d]", path, i), child)
+	}
+	if e.Extra != nil {
+		v.element(path+".extra", *e.Extra)
+	}
+}
+
+func (v *validator) columns(path string, columns []Column) {
+	for i, c := range columns {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		if c.Tag == "" {
+			v.add(p+".tag", "tag is required")
+		}
+		if (c.Width == "weighted" || c.Weight != 0) && (c.Weight < MinColumnWeight || c.Weight > MaxColumnWeight) {
+			v.add(p+".weight", "column weight %d out of range [%d, %d]", c.Weight, MinColumnWeight, MaxColumnWeight)
+		}
+		v.elementList(p+".elements", c.Elements)
+	}
+
+	// 卡片2.0中一组按钮以多列布局横向排列
+	buttons := 0
+	for _, c := range columns {
+		buttons += countButtons(c.Elements)
+	}
+	if buttons > MaxButtons {
+		v.add(path, "%d buttons exceeds %d", buttons, MaxButtons)
+	}
+}
+
+func (v *validator) table(path string, t TableElement) {
+	if t.PageSize < 0 || t.PageSize > MaxTablePageSize {
+		v.add(path+".page_size", "page size %d out of range [1, %d]", t.PageSize, MaxTablePageSize)
+	}
+	names := make(map[string]bool, len(t.Columns))
+	for i, c := range t.Columns {
+		p := fmt.Sprintf("%s.columns[%d]", path, i)
+		if c.Name == "" {
+			v.add(p+".name", "name is required")
+		} else if names[c.Name] {
+			v.add(p+".name", "duplicate column name %q", c.Name)
+		}
+		names[c.Name] = true
+		if c.DataType == "" {
+			v.add(p+".data_type", "data type is required")
+		}
+	}
+}
+
+func (v *validator) chart(path string, c ChartElement) {
+	if len(c.ChartSpec) == 0 {
+		v.add(path+".chart_spec", "chart spec is required")
+	}
+	switch c.AspectRatio {
+	case "", ChartAspect1x1, ChartAspect2x1, ChartAspect4x3, ChartAspect16x9:
+	default:
+		v.add(path+".aspect_ratio", "invalid aspect ratio %q", c.AspectRatio)
+	}
+	switch c.ColorTheme {
+	case "", ChartThemeBrand, ChartThemeRainbow, ChartThemeComplementary, ChartThemeConverse, ChartThemePrimary:
+	default:
+		v.add(path+".color_theme", "invalid color theme %q", c.ColorTheme)
+	}
+}
+
+func (v *validator) form(path string, f FormElement) {
+	if v.inForm {
+		v.add(path, "form cannot be nested in another form")
+	}
+	if f.Name == "" {
+		v.add(path+".name", "form name is required")
+	} else {
+		v.uniqueName(path, f.Name)
+	}
+
+	inForm, submits := v.inForm, v.submits
+	v.inForm, v.submits = true, 0
+	v.elementList(path+".elements", f.Elements)
+	if v.submits == 0 {
+		v.add(path, "form has no submit button")
+	}
+	v.inForm, v.submits = inForm, submits
+}
+
+// field 校验组件的 name，表单中的组件必须设置 name
+func (v *validator) field(path, name string) {
+	if name != "" {
+		v.uniqueName(path, name)
+	} else if v.inForm {
+		v.add(path+".name", "name is required in form")
+	}
+}
+
+func (v *validator) uniqueName(path, name string) {
+	if prev, ok := v.names[name]; ok {
+		v.add(path+".name", "duplicate name %q, already used at %s", name, prev)
+		return
+	}
+	if v.names == nil {
+		v.names = make(map[string]string)
+	}
+	v.names[name] = path
+}
+
+// formButton 校验表单的提交、重置按钮，只能放在表单中
+func (v *validator) formButton(path, actionType, formActionType string) {
+	if !isFormButton(actionType, formActionType) {
+		return
+	}
+	if !v.inForm {
+		v.add(path, "form button must be inside a form")
+	} else if isFormSubmit(actionType, formActionType) {
+		v.submits++
+	}
+}
+
+func (v *validator) options(path string, options []Option) {
+	if len(options) == 0 {
+		v.add(path, "options are required")
+	}
+	for i := range options {
+		v.text(fmt.Sprintf("%s[%d].text", path, i), &options[i].Text)
+	}
+}
+
+// countButtons 统计组件列表中的按钮数量
+func countButtons(elements []CardElement) int {
+	n := 0
+	for _, e := range elements {
+		if e != nil && e.ElementTag() == "button" {
+			n++
+		}
+	}
+	return n
+}
+
+func (v *validator) actions(path string, actions []Action) {
+	if len(actions) > MaxButtons {
+		v.add(path, "%d buttons exceeds %d", len(actions), MaxButtons)
+	}
+	for i, a := range actions {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		if a.Tag == "" {
+			v.add(p+".tag", "tag is required")
+		}
+		v.text(p+".text", a.Text)
+		v.optionalURL(p+".url", a.Url)
+		v.formButton(p, a.ActionType, "")
+		v.field(p, a.Name)
+		for j, o := range a.Options {
+			if o != nil {
+				v.optionalURL(fmt.Sprintf("%s.options[%d].url", p, j), o.Url)
+			}
+		}
+	}
+}
+
+func (v *validator) button(path string, b ButtonElement) {
+	v.text(path+".text", b.Text)
+	v.optionalURL(path+".url", b.Url)
+	v.formButton(path, b.ActionType, b.FormActionType)
+	v.field(path, b.Name)
+	for i, behavior := range b.Behaviors {
+		if behavior.Type != "open_url" {
+			continue
+		}
+		p := fmt.Sprintf("%s.behaviors[%d]", path, i)
+		v.url(p+".default_url", behavior.DefaultUrl)
+		v.optionalURL(p+".android_url", behavior.AndroidUrl)
+		v.optionalURL(p+".ios_url", behavior.IosUrl)
+		v.optionalURL(p+".pc_url", behavior.PCUrl)
+	}
+}
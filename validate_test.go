@@ -0,0 +1,176 @@
+package bot
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// validationPaths 返回校验错误的路径列表
+func validationPaths(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("应该返回 ValidationErrors: %v", err)
+	}
+	paths := make(map[string]string, len(errs))
+	for _, e := range errs {
+		paths[e.Path] = e.Message
+	}
+	return paths
+}
+
+// 测试有效的消息
+func TestValidateValid(t *testing.T) {
+	valid := []*Msg{
+		FormatMsg(&FeishuMsg{
+			Title:         "标题",
+			MarkdownArray: [][2]string{{"状态", "成功"}},
+			Images:        []string{"img_v3_025h_xxxx"},
+			Actions:       []Action{CreatePrimaryButtonElement("查看", "https://example.com")},
+			Link:          "https://example.com",
+			HeaderColor:   ColorGreen,
+		}),
+		FormatMsg(&FeishuMsg{Title: "标题", Actions: []Action{CreateButtonElement("查看", "https://example.com")}, Schema: CardSchemaV2}),
+		NewTextMsg("你好"),
+	}
+	for _, msg := range valid {
+		if err := Validate(msg); err != nil {
+			t.Errorf("%s 消息不应该返回错误: %v", msg.MsgType, err)
+		}
+	}
+}
+
+// 测试校验错误及其 JSON 路径
+func TestValidateErrors(t *testing.T) {
+	msg := &Msg{
+		MsgType: MsgTypeInteractive,
+		Card: Card{
+			Header:   Header{Title: Text{Tag: "plain_text"}, Template: "pink"},
+			CardLink: &CardLink{Url: "javascript:alert(1)"},
+			Elements: []CardElement{
+				CreateMarkdownElement("内容"),
+				CreateImageElement("v3_xxx", "图片"),
+				CreateColumnSetElement([]Column{{Tag: "column", Width: "weighted", Weight: 6}}, "none"),
+				Element{Tag: "action", Actions: []Action{{Tag: "button", Text: &Text{Tag: "plain_text", Content: "查看"}, Url: "ftp://example.com"}}},
+				Element{Content: "缺少 tag"},
+				ButtonElement{Text: &Text{Content: "按钮"}, Behaviors: []Behavior{{Type: "open_url"}}},
+			},
+		},
+	}
+
+	paths := validationPaths(t, Validate(msg))
+	expected := []string{
+		"card.header.title.content",
+		"card.header.template",
+		"card.card_link.url",
+		"card.elements[1].img_key",
+		"card.elements[2].columns[0].weight",
+		"card.elements[3].actions[0].url",
+		"card.elements[4].tag",
+		"card.elements[5].text.tag",
+		"card.elements[5].behaviors[0].default_url",
+	}
+	for _, path := range expected {
+		if _, ok := paths[path]; !ok {
+			t.Errorf("缺少 %s 的校验错误: %v", path, paths)
+		}
+	}
+	if len(paths) != len(expected) {
+		t.Errorf("校验错误数量不正确: 期望 %d, 实际 %d: %v", len(expected), len(paths), paths)
+	}
+}
+
+// 测试缺少标题和组件
+func TestValidateEmptyCard(t *testing.T) {
+	paths := validationPaths(t, Validate(&Msg{MsgType: MsgTypeInteractive}))
+	for _, p := range []string{"card.header.title", "card.elements"} {
+		if _, ok := paths[p]; !ok {
+			t.Errorf("缺少 %s 的校验错误: %v", p, paths)
+		}
+	}
+
+	msg := NewCardMsg(&Card{
+		Schema: string(CardSchemaV2),
+		Header: Header{Title: Text{Tag: "plain_text", Content: " "}},
+		Body:   &CardBody{},
+	})
+	paths = validationPaths(t, Validate(msg))
+	for _, p := range []string{"card.header.title.content", "card.body.elements"} {
+		if _, ok := paths[p]; !ok {
+			t.Errorf("卡片2.0 缺少 %s 的校验错误: %v", p, paths)
+		}
+	}
+
+	// 多语言卡片可以只设置各语言的标题和内容
+	msg = NewCardMsg(&Card{I18nElements: &I18nElements{
+		ZhCn: &I18nElement{
+			Header:   &Header{Title: Text{Tag: "plain_text", Content: "标题"}},
+			Elements: []CardElement{CreateMarkdownElement("内容")},
+		},
+		EnUs: &I18nElement{},
+	}})
+	paths = validationPaths(t, Validate(msg))
+	if _, ok := paths["card.i18n_elements.en_us.elements"]; !ok || len(paths) != 1 {
+		t.Errorf("只有英文内容为空应该返回错误: %v", paths)
+	}
+}
+
+// 测试大小、组件数量、按钮数量限制
+func TestValidateLimits(t *testing.T) {
+	msg := FormatMsg(&FeishuMsg{Title: "标题", MarkdownArray: [][2]string{{"内容", strings.Repeat("a", MaxCardSize)}}})
+	if paths := validationPaths(t, Validate(msg)); !strings.Contains(paths[""], "payload size") {
+		t.Errorf("超出大小限制应该返回错误: %v", paths)
+	}
+
+	elements := make([]CardElement, 0, MaxCardElements+1)
+	for i := 0; i <= MaxCardElements; i++ {
+		elements = append(elements, HrElement{})
+	}
+	msg = NewCardMsg(&Card{Header: Header{Title: Text{Tag: "plain_text", Content: "标题"}}, Elements: elements})
+	if paths := validationPaths(t, Validate(msg)); !strings.Contains(paths["card"], "elements") {
+		t.Errorf("超出组件数量限制应该返回错误: %v", paths)
+	}
+
+	actions := make([]Action, 0, MaxButtons+1)
+	for i := 0; i <= MaxButtons; i++ {
+		actions = append(actions, CreateButtonElement("按钮", ""))
+	}
+	for _, schema := range []CardSchema{CardSchemaV1, CardSchemaV2} {
+		msg = FormatMsg(&FeishuMsg{Title: "标题", Actions: actions, Schema: schema})
+		if err := Validate(msg); err == nil || !strings.Contains(err.Error(), "buttons exceeds") {
+			t.Errorf("卡片%s超出按钮数量限制应该返回错误: %v", schema, err)
+		}
+	}
+}
+
+// 测试通过 WithValidation 在发送前校验
+func TestClientWithValidation(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	f := &FeishuMsg{Title: "标题", HeaderColor: "pink"}
+	err := SendFeishuMsg(server.URL, f, WithValidation())
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Errorf("应该返回校验错误: %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("校验失败不应该发出请求，实际 %d 次", calls)
+	}
+
+	// 未开启校验时照常发送
+	if err := SendFeishuMsg(server.URL, f); err != nil {
+		t.Errorf("发送失败: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("应该发出 1 次请求，实际 %d 次", calls)
+	}
+}